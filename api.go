@@ -25,8 +25,10 @@ import (
 
 // API is the object that contains all the functions that wrap those of the Telegram Bot API.
 type API struct {
-	token string
-	base  string
+	token           string
+	base            string
+	logger          Logger
+	instrumentation Instrumentation
 }
 
 // NewAPI returns a new API object.
@@ -37,6 +39,20 @@ func NewAPI(token string) API {
 	}
 }
 
+// SetLogger sets the Logger used to log each call to the Telegram API at the debug level,
+// together with its duration, HTTP status and error code.
+// The bot token is redacted from the logged errors.
+// A nil Logger disables the logging.
+func (a *API) SetLogger(l Logger) {
+	a.logger = l
+}
+
+// SetInstrumentation sets the Instrumentation whose hooks are called around each request to the Telegram API.
+// A nil Instrumentation disables it.
+func (a *API) SetInstrumentation(i Instrumentation) {
+	a.instrumentation = i
+}
+
 // GetUpdates is used to receive incoming updates using long polling.
 func (a API) GetUpdates(opts *UpdateOptions) (res APIResponseUpdate, err error) {
 	var url = fmt.Sprintf(
@@ -45,7 +61,7 @@ func (a API) GetUpdates(opts *UpdateOptions) (res APIResponseUpdate, err error)
 		querify(opts),
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -68,7 +84,7 @@ func (a API) SetWebhook(webhookURL string, dropPendingUpdates bool, opts *Webhoo
 	)
 
 	keyVal := map[string]string{"url": webhookURL}
-	cnt, err := sendPostForm(url, keyVal)
+	cnt, err := a.sendPostForm(url, keyVal)
 	if err != nil {
 		return
 	}
@@ -89,7 +105,7 @@ func (a API) DeleteWebhook(dropPendingUpdates bool) (res APIResponseBase, err er
 		dropPendingUpdates,
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -109,7 +125,7 @@ func (a API) GetWebhookInfo() (res APIResponseWebhook, err error) {
 		a.base,
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -129,7 +145,7 @@ func (a API) GetMe() (res APIResponseUser, err error) {
 		a.base,
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -152,7 +168,7 @@ func (a API) LogOut() (res APIResponseBool, err error) {
 		a.base,
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -174,7 +190,7 @@ func (a API) Close() (res APIResponseBool, err error) {
 		a.base,
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -197,7 +213,7 @@ func (a API) SendMessage(text string, chatID int64, opts *MessageOptions) (res A
 		querify(opts),
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -222,7 +238,7 @@ func (a API) ForwardMessage(chatID, fromChatID int64, messageID int, opts *Forwa
 		querify(opts),
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -249,7 +265,64 @@ func (a API) CopyMessage(chatID, fromChatID int64, messageID int, opts *CopyOpti
 		querify(opts),
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(cnt, &res); err != nil {
+		return
+	}
+
+	err = check(res)
+	return
+}
+
+// ForwardMessages is used to forward multiple messages of any kind, at most MaxBatchMessages.
+// The message IDs must be in strictly increasing order; messages that can't be found or forwarded are skipped.
+// Album grouping is kept for the forwarded messages.
+func (a API) ForwardMessages(chatID, fromChatID int64, messageIDs []int, opts *ForwardOptions) (res APIResponseMessageIDs, err error) {
+	jsn, _ := json.Marshal(messageIDs)
+
+	var url = fmt.Sprintf(
+		"%sforwardMessages?chat_id=%d&from_chat_id=%d&message_ids=%s&%s",
+		a.base,
+		chatID,
+		fromChatID,
+		encode(string(jsn)),
+		querify(opts),
+	)
+
+	cnt, err := a.sendGetRequest(url)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(cnt, &res); err != nil {
+		return
+	}
+
+	err = check(res)
+	return
+}
+
+// CopyMessages is used to copy multiple messages of any kind, at most MaxBatchMessages.
+// The message IDs must be in strictly increasing order; messages that can't be found or copied are skipped.
+// Service messages and invoice messages can't be copied.
+// Album grouping is kept for the copied messages.
+func (a API) CopyMessages(chatID, fromChatID int64, messageIDs []int, opts *CopyMessagesOptions) (res APIResponseMessageIDs, err error) {
+	jsn, _ := json.Marshal(messageIDs)
+
+	var url = fmt.Sprintf(
+		"%scopyMessages?chat_id=%d&from_chat_id=%d&message_ids=%s&%s",
+		a.base,
+		chatID,
+		fromChatID,
+		encode(string(jsn)),
+		querify(opts),
+	)
+
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -271,7 +344,7 @@ func (a API) SendPhoto(file InputFile, chatID int64, opts *PhotoOptions) (res AP
 		querify(opts),
 	)
 
-	cnt, err := sendFile(file, InputFile{}, url, "photo")
+	cnt, err := a.sendFile(file, InputFile{}, url, "photo")
 	if err != nil {
 		return
 	}
@@ -300,7 +373,7 @@ func (a API) SendAudio(file InputFile, chatID int64, opts *AudioOptions) (res AP
 		thumb = opts.Thumb
 	}
 
-	cnt, err := sendFile(file, thumb, url, "audio")
+	cnt, err := a.sendFile(file, thumb, url, "audio")
 	if err != nil {
 		return
 	}
@@ -327,7 +400,7 @@ func (a API) SendDocument(file InputFile, chatID int64, opts *DocumentOptions) (
 		thumb = opts.Thumb
 	}
 
-	cnt, err := sendFile(file, thumb, url, "document")
+	cnt, err := a.sendFile(file, thumb, url, "document")
 	if err != nil {
 		return
 	}
@@ -355,7 +428,7 @@ func (a API) SendVideo(file InputFile, chatID int64, opts *VideoOptions) (res AP
 		thumb = opts.Thumb
 	}
 
-	cnt, err := sendFile(file, thumb, url, "video")
+	cnt, err := a.sendFile(file, thumb, url, "video")
 	if err != nil {
 		return
 	}
@@ -382,7 +455,7 @@ func (a API) SendAnimation(file InputFile, chatID int64, opts *AnimationOptions)
 		thumb = opts.Thumb
 	}
 
-	cnt, err := sendFile(file, thumb, url, "animation")
+	cnt, err := a.sendFile(file, thumb, url, "animation")
 	if err != nil {
 		return
 	}
@@ -405,7 +478,7 @@ func (a API) SendVoice(file InputFile, chatID int64, opts *VoiceOptions) (res AP
 		querify(opts),
 	)
 
-	cnt, err := sendFile(file, InputFile{}, url, "voice")
+	cnt, err := a.sendFile(file, InputFile{}, url, "voice")
 	if err != nil {
 		return
 	}
@@ -432,7 +505,7 @@ func (a API) SendVideoNote(file InputFile, chatID int64, opts *VideoNoteOptions)
 		thumb = opts.Thumb
 	}
 
-	cnt, err := sendFile(file, thumb, url, "video_note")
+	cnt, err := a.sendFile(file, thumb, url, "video_note")
 	if err != nil {
 		return
 	}
@@ -455,7 +528,7 @@ func (a API) SendMediaGroup(chatID int64, media []GroupableInputMedia, opts *Med
 		querify(opts),
 	)
 
-	cnt, err := sendMediaFiles(url, false, toInputMedia(media)...)
+	cnt, err := a.sendMediaFiles(url, false, toInputMedia(media)...)
 	if err != nil {
 		return
 	}
@@ -479,7 +552,7 @@ func (a API) SendLocation(chatID int64, latitude, longitude float64, opts *Locat
 		querify(opts),
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -504,7 +577,7 @@ func (a API) EditMessageLiveLocation(msg MessageIDOptions, latitude, longitude f
 		querify(opts),
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -526,7 +599,7 @@ func (a API) StopMessageLiveLocation(msg MessageIDOptions, opts *MessageReplyMar
 		querify(opts),
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -552,7 +625,7 @@ func (a API) SendVenue(chatID int64, latitude, longitude float64, title, address
 		querify(opts),
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -576,7 +649,7 @@ func (a API) SendContact(phoneNumber, firstName string, chatID int64, opts *Cont
 		querify(opts),
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -601,12 +674,12 @@ func (a API) SendPoll(chatID int64, question string, options []string, opts *Pol
 		"%ssendPoll?chat_id=%d&question=%s&options=%s&%s",
 		a.base,
 		chatID,
-		question,
+		encode(question),
 		encode(string(pollOpts)),
 		querify(opts),
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -629,7 +702,7 @@ func (a API) SendDice(chatID int64, emoji DiceEmoji, opts *BaseOptions) (res API
 		querify(opts),
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -644,15 +717,16 @@ func (a API) SendDice(chatID int64, emoji DiceEmoji, opts *BaseOptions) (res API
 
 // SendChatAction is used to tell the user that something is happening on the bot's side.
 // The status is set for 5 seconds or less (when a message arrives from your bot, Telegram clients clear its typing status).
-func (a API) SendChatAction(action ChatAction, chatID int64) (res APIResponseBool, err error) {
+func (a API) SendChatAction(action ChatAction, chatID int64, opts *ChatActionOptions) (res APIResponseBool, err error) {
 	var url = fmt.Sprintf(
-		"%ssendChatAction?chat_id=%d&action=%s",
+		"%ssendChatAction?chat_id=%d&action=%s&%s",
 		a.base,
 		chatID,
 		action,
+		querify(opts),
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -674,7 +748,7 @@ func (a API) GetUserProfilePhotos(userID int64, opts *UserProfileOptions) (res A
 		querify(opts),
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -699,7 +773,7 @@ func (a API) GetFile(fileID string) (res APIResponseFile, err error) {
 		fileID,
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -716,7 +790,7 @@ func (a API) GetFile(fileID string) (res APIResponseFile, err error) {
 // This function is callable for at least 1 hour since the call to GetFile.
 // When the download expires a new one can be requested by calling GetFile again.
 func (a API) DownloadFile(filePath string) ([]byte, error) {
-	return sendGetRequest(fmt.Sprintf(
+	return a.sendGetRequest(fmt.Sprintf(
 		"https://api.telegram.org/file/bot%s/%s",
 		a.token,
 		filePath,
@@ -736,7 +810,7 @@ func (a API) BanChatMember(chatID, userID int64, opts *BanOptions) (res APIRespo
 		querify(opts),
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -764,7 +838,7 @@ func (a API) UnbanChatMember(chatID, userID int64, opts *UnbanOptions) (res APIR
 		querify(opts),
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -795,7 +869,7 @@ func (a API) RestrictChatMember(chatID, userID int64, permissions ChatPermission
 		querify(opts),
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -819,7 +893,7 @@ func (a API) PromoteChatMember(chatID, userID int64, opts *PromoteOptions) (res
 		querify(opts),
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -842,7 +916,7 @@ func (a API) SetChatAdministratorCustomTitle(chatID, userID int64, customTitle s
 		encode(customTitle),
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -866,7 +940,7 @@ func (a API) BanChatSenderChat(chatID, senderChatID int64) (res APIResponseBool,
 		senderChatID,
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -889,7 +963,7 @@ func (a API) UnbanChatSenderChat(chatID, senderChatID int64) (res APIResponseBoo
 		senderChatID,
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -918,7 +992,7 @@ func (a API) SetChatPermissions(chatID int64, permissions ChatPermissions) (res
 		encode(perm),
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -941,7 +1015,7 @@ func (a API) ExportChatInviteLink(chatID int64) (res APIResponseString, err erro
 		chatID,
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -965,7 +1039,7 @@ func (a API) CreateChatInviteLink(chatID int64, opts *InviteLinkOptions) (res AP
 		querify(opts),
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -989,7 +1063,7 @@ func (a API) EditChatInviteLink(chatID int64, inviteLink string, opts *InviteLin
 		querify(opts),
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -1013,7 +1087,7 @@ func (a API) RevokeChatInviteLink(chatID int64, inviteLink string) (res APIRespo
 		encode(inviteLink),
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -1036,7 +1110,7 @@ func (a API) ApproveChatJoinRequest(chatID, userID int64) (res APIResponseBool,
 		userID,
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -1059,7 +1133,7 @@ func (a API) DeclineChatJoinRequest(chatID, userID int64) (res APIResponseBool,
 		userID,
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -1082,7 +1156,7 @@ func (a API) SetChatPhoto(file InputFile, chatID int64) (res APIResponseBool, er
 		chatID,
 	)
 
-	cnt, err := sendFile(file, InputFile{}, url, "photo")
+	cnt, err := a.sendFile(file, InputFile{}, url, "photo")
 	if err != nil {
 		return
 	}
@@ -1105,7 +1179,7 @@ func (a API) DeleteChatPhoto(chatID int64) (res APIResponseBool, err error) {
 		chatID,
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -1129,7 +1203,7 @@ func (a API) SetChatTitle(chatID int64, title string) (res APIResponseBool, err
 		encode(title),
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -1152,7 +1226,7 @@ func (a API) SetChatDescription(chatID int64, description string) (res APIRespon
 		encode(description),
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -1177,7 +1251,7 @@ func (a API) PinChatMessage(chatID int64, messageID int, opts *PinMessageOptions
 		querify(opts),
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -1201,7 +1275,7 @@ func (a API) UnpinChatMessage(chatID int64, messageID int) (res APIResponseBool,
 		messageID,
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -1224,7 +1298,7 @@ func (a API) UnpinAllChatMessages(chatID int64) (res APIResponseBool, err error)
 		chatID,
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -1245,7 +1319,7 @@ func (a API) LeaveChat(chatID int64) (res APIResponseBool, err error) {
 		chatID,
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -1267,7 +1341,7 @@ func (a API) GetChat(chatID int64) (res APIResponseChat, err error) {
 		chatID,
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -1288,7 +1362,7 @@ func (a API) GetChatAdministrators(chatID int64) (res APIResponseAdministrators,
 		chatID,
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -1309,7 +1383,7 @@ func (a API) GetChatMemberCount(chatID int64) (res APIResponseInteger, err error
 		chatID,
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -1331,7 +1405,7 @@ func (a API) GetChatMember(chatID, userID int64) (res APIResponseChatMember, err
 		userID,
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -1355,7 +1429,7 @@ func (a API) SetChatStickerSet(chatID int64, stickerSetName string) (res APIResp
 		encode(stickerSetName),
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -1378,7 +1452,7 @@ func (a API) DeleteChatStickerSet(chatID int64) (res APIResponseBool, err error)
 		chatID,
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -1401,7 +1475,7 @@ func (a API) AnswerCallbackQuery(callbackID string, opts *CallbackQueryOptions)
 		querify(opts),
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -1421,11 +1495,11 @@ func (a API) SetMyCommands(opts *CommandOptions, commands ...BotCommand) (res AP
 	var url = fmt.Sprintf(
 		"%ssetMyCommands?commands=%s&%s",
 		a.base,
-		jsn,
+		encode(string(jsn)),
 		querify(opts),
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -1446,7 +1520,7 @@ func (a API) DeleteMyCommands(opts *CommandOptions) (res APIResponseBool, err er
 		querify(opts),
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -1467,7 +1541,227 @@ func (a API) GetMyCommands(opts *CommandOptions) (res APIResponseCommands, err e
 		querify(opts),
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(cnt, &res); err != nil {
+		return
+	}
+
+	err = check(res)
+	return
+}
+
+// SetMyName is used to change the bot's name for the given user language.
+// An empty name removes the dedicated name for the given language.
+func (a API) SetMyName(name, languageCode string) (res APIResponseBool, err error) {
+	var url = fmt.Sprintf(
+		"%ssetMyName?name=%s&language_code=%s",
+		a.base,
+		encode(name),
+		encode(languageCode),
+	)
+
+	cnt, err := a.sendGetRequest(url)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(cnt, &res); err != nil {
+		return
+	}
+
+	err = check(res)
+	return
+}
+
+// GetMyName is used to get the current bot's name for the given user language.
+func (a API) GetMyName(languageCode string) (res APIResponseBotName, err error) {
+	var url = fmt.Sprintf(
+		"%sgetMyName?language_code=%s",
+		a.base,
+		encode(languageCode),
+	)
+
+	cnt, err := a.sendGetRequest(url)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(cnt, &res); err != nil {
+		return
+	}
+
+	err = check(res)
+	return
+}
+
+// SetMyDescription is used to change the bot's description for the given user language.
+// The description is shown in the chat with the bot if the chat is empty.
+// An empty description removes the dedicated description for the given language.
+func (a API) SetMyDescription(description, languageCode string) (res APIResponseBool, err error) {
+	var url = fmt.Sprintf(
+		"%ssetMyDescription?description=%s&language_code=%s",
+		a.base,
+		encode(description),
+		encode(languageCode),
+	)
+
+	cnt, err := a.sendGetRequest(url)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(cnt, &res); err != nil {
+		return
+	}
+
+	err = check(res)
+	return
+}
+
+// GetMyDescription is used to get the current bot's description for the given user language.
+func (a API) GetMyDescription(languageCode string) (res APIResponseBotDescription, err error) {
+	var url = fmt.Sprintf(
+		"%sgetMyDescription?language_code=%s",
+		a.base,
+		encode(languageCode),
+	)
+
+	cnt, err := a.sendGetRequest(url)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(cnt, &res); err != nil {
+		return
+	}
+
+	err = check(res)
+	return
+}
+
+// SetMyShortDescription is used to change the bot's short description for the given user language.
+// The short description is shown on the bot's profile page and is sent together with the link when users share the bot.
+// An empty short description removes the dedicated short description for the given language.
+func (a API) SetMyShortDescription(shortDescription, languageCode string) (res APIResponseBool, err error) {
+	var url = fmt.Sprintf(
+		"%ssetMyShortDescription?short_description=%s&language_code=%s",
+		a.base,
+		encode(shortDescription),
+		encode(languageCode),
+	)
+
+	cnt, err := a.sendGetRequest(url)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(cnt, &res); err != nil {
+		return
+	}
+
+	err = check(res)
+	return
+}
+
+// GetMyShortDescription is used to get the current bot's short description for the given user language.
+func (a API) GetMyShortDescription(languageCode string) (res APIResponseBotShortDescription, err error) {
+	var url = fmt.Sprintf(
+		"%sgetMyShortDescription?language_code=%s",
+		a.base,
+		encode(languageCode),
+	)
+
+	cnt, err := a.sendGetRequest(url)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(cnt, &res); err != nil {
+		return
+	}
+
+	err = check(res)
+	return
+}
+
+// SetChatMenuButton is used to change the bot's menu button in a private chat, or the default menu button.
+func (a API) SetChatMenuButton(opts *SetChatMenuButtonOptions) (res APIResponseBool, err error) {
+	var url = fmt.Sprintf(
+		"%ssetChatMenuButton?%s",
+		a.base,
+		querify(opts),
+	)
+
+	cnt, err := a.sendGetRequest(url)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(cnt, &res); err != nil {
+		return
+	}
+
+	err = check(res)
+	return
+}
+
+// GetChatMenuButton is used to get the current value of the bot's menu button in a private chat, or the default menu button.
+func (a API) GetChatMenuButton(opts *GetChatMenuButtonOptions) (res APIResponseMenuButton, err error) {
+	var url = fmt.Sprintf(
+		"%sgetChatMenuButton?%s",
+		a.base,
+		querify(opts),
+	)
+
+	cnt, err := a.sendGetRequest(url)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(cnt, &res); err != nil {
+		return
+	}
+
+	err = check(res)
+	return
+}
+
+// SetMyDefaultAdministratorRights is used to change the default administrator rights requested by the bot
+// when it's added as an administrator to groups or channels.
+// These rights will be suggested to users, but they are free to modify the list before adding the bot.
+func (a API) SetMyDefaultAdministratorRights(opts *SetMyDefaultAdministratorRightsOptions) (res APIResponseBool, err error) {
+	var url = fmt.Sprintf(
+		"%ssetMyDefaultAdministratorRights?%s",
+		a.base,
+		querify(opts),
+	)
+
+	cnt, err := a.sendGetRequest(url)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(cnt, &res); err != nil {
+		return
+	}
+
+	err = check(res)
+	return
+}
+
+// GetMyDefaultAdministratorRights is used to get the current default administrator rights of the bot.
+func (a API) GetMyDefaultAdministratorRights(opts *GetMyDefaultAdministratorRightsOptions) (res APIResponseChatAdministratorRights, err error) {
+	var url = fmt.Sprintf(
+		"%sgetMyDefaultAdministratorRights?%s",
+		a.base,
+		querify(opts),
+	)
+
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -1490,7 +1784,7 @@ func (a API) EditMessageText(text string, msg MessageIDOptions, opts *MessageTex
 		querify(opts),
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -1512,7 +1806,7 @@ func (a API) EditMessageCaption(msg MessageIDOptions, opts *MessageCaptionOption
 		querify(opts),
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -1538,7 +1832,7 @@ func (a API) EditMessageMedia(msg MessageIDOptions, media InputMedia, opts *Mess
 		querify(opts),
 	)
 
-	cnt, err := sendMediaFiles(url, true, media)
+	cnt, err := a.sendMediaFiles(url, true, media)
 	if err != nil {
 		return
 	}
@@ -1560,7 +1854,7 @@ func (a API) EditMessageReplyMarkup(msg MessageIDOptions, opts *MessageReplyMark
 		querify(opts),
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -1583,7 +1877,7 @@ func (a API) StopPoll(chatID int64, messageID int, opts *MessageReplyMarkup) (re
 		querify(opts),
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -1612,7 +1906,32 @@ func (a API) DeleteMessage(chatID int64, messageID int) (res APIResponseBase, er
 		messageID,
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(cnt, &res); err != nil {
+		return
+	}
+
+	err = check(res)
+	return
+}
+
+// DeleteMessages is used to delete multiple messages simultaneously, at most MaxBatchMessages.
+// Messages that can't be found or deleted are skipped.
+func (a API) DeleteMessages(chatID int64, messageIDs []int) (res APIResponseBool, err error) {
+	jsn, _ := json.Marshal(messageIDs)
+
+	var url = fmt.Sprintf(
+		"%sdeleteMessages?chat_id=%d&message_ids=%s",
+		a.base,
+		chatID,
+		encode(string(jsn)),
+	)
+
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -5,6 +5,7 @@ import (
 	"io"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 )
@@ -869,6 +870,7 @@ func TestSendChatAction(t *testing.T) {
 	_, err := api.SendChatAction(
 		Typing,
 		chatID,
+		nil,
 	)
 
 	if err != nil {
@@ -1344,3 +1346,82 @@ func TestDeleteMessage(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestSetChatMenuButton(t *testing.T) {
+	a, reqs := newMockAPI(t, "true")
+
+	_, err := a.SetChatMenuButton(
+		&SetChatMenuButtonOptions{
+			ChatID: chatID,
+			MenuButton: MenuButton{
+				Type:   MBTWebApp,
+				Text:   "Open",
+				WebApp: &WebAppInfo{URL: "https://example.com"},
+			},
+		},
+	)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := <-reqs
+	if req.method != "setChatMenuButton" || req.query.Get("chat_id") != fmt.Sprint(chatID) {
+		t.Fatalf("unexpected request %s %v", req.method, req.query)
+	}
+	if mb := req.query.Get("menu_button"); mb != `{"type":"web_app","text":"Open","web_app":{"url":"https://example.com"}}` {
+		t.Fatalf("unexpected menu_button %s", mb)
+	}
+}
+
+func TestGetChatMenuButton(t *testing.T) {
+	a, _ := newMockAPI(t, `{"type":"commands"}`)
+
+	res, err := a.GetChatMenuButton(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.Result == nil || res.Result.Type != MBTCommands {
+		t.Fatalf("unexpected result %+v", res.Result)
+	}
+}
+
+func TestSetMyDefaultAdministratorRights(t *testing.T) {
+	a, reqs := newMockAPI(t, "true")
+
+	_, err := a.SetMyDefaultAdministratorRights(
+		&SetMyDefaultAdministratorRightsOptions{
+			Rights:      ChatAdministratorRights{CanDeleteMessages: true, CanPinMessages: true},
+			ForChannels: true,
+		},
+	)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := <-reqs
+	if req.query.Get("for_channels") != "true" {
+		t.Fatalf("missing for_channels in %v", req.query)
+	}
+	if rights := req.query.Get("rights"); !strings.Contains(rights, `"can_delete_messages":true`) || !strings.Contains(rights, `"can_pin_messages":true`) {
+		t.Fatalf("unexpected rights %s", rights)
+	}
+}
+
+func TestGetMyDefaultAdministratorRights(t *testing.T) {
+	a, reqs := newMockAPI(t, `{"is_anonymous":false,"can_manage_chat":true,"can_delete_messages":true}`)
+
+	res, err := a.GetMyDefaultAdministratorRights(&GetMyDefaultAdministratorRightsOptions{ForChannels: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if req := <-reqs; req.method != "getMyDefaultAdministratorRights" || req.query.Get("for_channels") != "true" {
+		t.Fatalf("unexpected request %s %v", req.method, req.query)
+	}
+	if res.Result == nil || !res.Result.CanManageChat || !res.Result.CanDeleteMessages {
+		t.Fatalf("unexpected result %+v", res.Result)
+	}
+}
@@ -0,0 +1,204 @@
+/*
+ * Echotron
+ * Copyright (C) 2018-2022 The Echotron Devs
+ *
+ * Echotron is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Echotron is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package echotron
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+const (
+	// MaxBatchMessages is the maximum number of messages accepted by ForwardMessages, CopyMessages and DeleteMessages.
+	MaxBatchMessages = 100
+	// MaxBatchFallback is the maximum number of messages processed one at a time by a single call to
+	// ForwardMessagesBatched, CopyMessagesBatched or DeleteMessagesBatched.
+	MaxBatchFallback = 20
+)
+
+// batchFallbackErrors are the descriptions of the errors caused by single messages of a request,
+// which justify processing its messages one at a time.
+var batchFallbackErrors = []string{
+	"not found",
+	"can't be forwarded",
+	"can't be copied",
+	"can't be deleted",
+}
+
+// BatchResult contains the outcome of a batch operation for a single message.
+type BatchResult struct {
+	MessageID int
+	// NewMessageID is the ID of the forwarded or copied message.
+	// It's 0 for deletions and when Telegram skipped some of the messages sent in the same
+	// request, since the returned IDs can't be matched with the original ones anymore.
+	NewMessageID int
+	Err          error
+}
+
+// ForwardMessagesBatched forwards any number of messages, splitting them in requests of at most MaxBatchMessages
+// messages each, see ForwardMessages. If a request is rejected by Telegram because of some of its messages,
+// e.g. one that can't be found, up to MaxBatchFallback of the messages it contained are forwarded one at a time,
+// so that each result reports the outcome for its own message. The others get the error of the request.
+// The returned error is the first one encountered, if any.
+func (a API) ForwardMessagesBatched(chatID, fromChatID int64, messageIDs []int, opts *ForwardOptions) ([]BatchResult, error) {
+	return runBatch(
+		messageIDs,
+		func(ids []int) ([]*MessageID, error) {
+			res, err := a.ForwardMessages(chatID, fromChatID, ids, opts)
+			return res.Result, err
+		},
+		func(id int) (int, error) {
+			res, err := a.ForwardMessage(chatID, fromChatID, id, opts)
+			if err != nil || res.Result == nil {
+				return 0, err
+			}
+			return res.Result.ID, nil
+		},
+	)
+}
+
+// CopyMessagesBatched copies any number of messages, splitting them in requests of at most MaxBatchMessages
+// messages each, see CopyMessages. If a request is rejected by Telegram because of some of its messages,
+// e.g. one that can't be found, up to MaxBatchFallback of the messages it contained are copied one at a time,
+// so that each result reports the outcome for its own message. The others get the error of the request.
+// The returned error is the first one encountered, if any.
+func (a API) CopyMessagesBatched(chatID, fromChatID int64, messageIDs []int, opts *CopyMessagesOptions) ([]BatchResult, error) {
+	var single *CopyOptions
+	if opts != nil {
+		single = &CopyOptions{
+			MessageThreadID:     opts.MessageThreadID,
+			DisableNotification: opts.DisableNotification,
+			ProtectContent:      opts.ProtectContent,
+		}
+	}
+
+	return runBatch(
+		messageIDs,
+		func(ids []int) ([]*MessageID, error) {
+			res, err := a.CopyMessages(chatID, fromChatID, ids, opts)
+			return res.Result, err
+		},
+		func(id int) (int, error) {
+			res, err := a.CopyMessage(chatID, fromChatID, id, single)
+			if err != nil || res.Result == nil {
+				return 0, err
+			}
+			return res.Result.MessageID, nil
+		},
+	)
+}
+
+// DeleteMessagesBatched deletes any number of messages, splitting them in requests of at most MaxBatchMessages
+// messages each, see DeleteMessages. If a request is rejected by Telegram because of some of its messages,
+// e.g. one that can't be found, up to MaxBatchFallback of the messages it contained are deleted one at a time,
+// so that each result reports the outcome for its own message. The others get the error of the request.
+// The returned error is the first one encountered, if any.
+func (a API) DeleteMessagesBatched(chatID int64, messageIDs []int) ([]BatchResult, error) {
+	return runBatch(
+		messageIDs,
+		func(ids []int) ([]*MessageID, error) {
+			_, err := a.DeleteMessages(chatID, ids)
+			return nil, err
+		},
+		func(id int) (int, error) {
+			_, err := a.DeleteMessage(chatID, id)
+			return 0, err
+		},
+	)
+}
+
+// runBatch sorts and deduplicates the message IDs, as required by Telegram, and processes them in chunks
+// of MaxBatchMessages with the batch function, falling back to the single function for at most
+// MaxBatchFallback messages of the chunks rejected by Telegram because of some of their messages.
+func runBatch(messageIDs []int, batch func([]int) ([]*MessageID, error), single func(int) (int, error)) (res []BatchResult, err error) {
+	ids := append([]int(nil), messageIDs...)
+	sort.Ints(ids)
+
+	var (
+		uniq     []int
+		fallback = MaxBatchFallback
+	)
+	for i, id := range ids {
+		if i == 0 || id != ids[i-1] {
+			uniq = append(uniq, id)
+		}
+	}
+
+	for start := 0; start < len(uniq); start += MaxBatchMessages {
+		end := start + MaxBatchMessages
+		if end > len(uniq) {
+			end = len(uniq)
+		}
+		chunk := uniq[start:end]
+
+		newIDs, e := batch(chunk)
+		switch {
+		case e == nil:
+			for i, id := range chunk {
+				r := BatchResult{MessageID: id}
+				if len(newIDs) == len(chunk) && newIDs[i] != nil {
+					r.NewMessageID = newIDs[i].MessageID
+				}
+				res = append(res, r)
+			}
+
+		case canRetrySingly(e):
+			for _, id := range chunk {
+				if fallback == 0 {
+					res = append(res, BatchResult{MessageID: id, Err: e})
+					continue
+				}
+
+				fallback--
+				newID, singleErr := single(id)
+				res = append(res, BatchResult{MessageID: id, NewMessageID: newID, Err: singleErr})
+			}
+
+		default:
+			for _, id := range chunk {
+				res = append(res, BatchResult{MessageID: id, Err: e})
+			}
+		}
+	}
+
+	for _, r := range res {
+		if r.Err != nil {
+			return res, r.Err
+		}
+	}
+	return res, nil
+}
+
+// canRetrySingly returns true if Telegram rejected the whole request because of some of its messages,
+// in which case the other messages can still be processed one at a time.
+// Any other error, e.g. a flood limit or missing rights, would affect each message as well.
+func canRetrySingly(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.ErrorCode() != 400 {
+		return false
+	}
+
+	desc := strings.ToLower(apiErr.Description())
+	for _, e := range batchFallbackErrors {
+		if strings.Contains(desc, e) {
+			return true
+		}
+	}
+	return false
+}
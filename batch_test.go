@@ -0,0 +1,153 @@
+package echotron
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// newBatchMockAPI returns an API instance talking to a local server which forwards the messages
+// by adding 1000 to their IDs and rejects message 150, both in batches and on its own.
+// The requests containing message 999 fail as if the bot had been removed from the chat.
+func newBatchMockAPI(t *testing.T) (API, func() []string) {
+	var (
+		calls []string
+		mu    sync.Mutex
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method := path.Base(r.URL.Path)
+		mu.Lock()
+		calls = append(calls, method)
+		mu.Unlock()
+
+		var ids []int
+		if v := r.URL.Query().Get("message_ids"); v != "" {
+			json.Unmarshal([]byte(v), &ids)
+		} else {
+			id, _ := strconv.Atoi(r.URL.Query().Get("message_id"))
+			ids = []int{id}
+		}
+
+		var results []string
+		for _, id := range ids {
+			switch id {
+			case 150:
+				w.Write([]byte(`{"ok":false,"error_code":400,"description":"Bad Request: message can't be forwarded"}`))
+				return
+			case 999:
+				w.Write([]byte(`{"ok":false,"error_code":403,"description":"Forbidden: bot was kicked from the group chat"}`))
+				return
+			}
+			results = append(results, fmt.Sprintf(`{"message_id":%d}`, id+1000))
+		}
+
+		switch method {
+		case "forwardMessages", "copyMessages":
+			w.Write([]byte(`{"ok":true,"result":[` + strings.Join(results, ",") + `]}`))
+		case "forwardMessage":
+			w.Write([]byte(`{"ok":true,"result":{"message_id":` + strconv.Itoa(ids[0]+1000) + `}}`))
+		default:
+			w.Write([]byte(`{"ok":true,"result":true}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	return API{token: "token", base: srv.URL + "/bottoken/"}, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return calls
+	}
+}
+
+func TestForwardMessagesBatched(t *testing.T) {
+	a, calls := newBatchMockAPI(t)
+
+	var ids []int
+	for i := 250; i > 0; i-- {
+		ids = append(ids, i)
+	}
+	// Duplicates are sent only once.
+	ids = append(ids, 1, 2)
+
+	res, err := a.ForwardMessagesBatched(chatID, chatID, ids, nil)
+	if err == nil || !strings.Contains(err.Error(), "can't be forwarded") {
+		t.Fatalf("expected the error of message 150, got %v", err)
+	}
+
+	if len(res) != 250 {
+		t.Fatalf("expected 250 results, got %d", len(res))
+	}
+
+	// Only the first MaxBatchFallback messages of the rejected batch are retried singly.
+	for _, r := range res {
+		rejected := r.MessageID > 100+MaxBatchFallback && r.MessageID <= 200
+
+		switch {
+		case rejected && r.Err == nil:
+			t.Fatalf("expected an error for message %d", r.MessageID)
+		case !rejected && (r.Err != nil || r.NewMessageID != r.MessageID+1000):
+			t.Fatalf("unexpected result %+v", r)
+		}
+	}
+
+	// One batch for 1-100, one rejected batch for 101-200 partially retried singly, one batch for 201-250.
+	if c := calls(); len(c) != 3+MaxBatchFallback || c[0] != "forwardMessages" || c[2] != "forwardMessage" || c[len(c)-1] != "forwardMessages" {
+		t.Fatalf("unexpected calls %d %v", len(c), c)
+	}
+}
+
+func TestDeleteMessagesBatched(t *testing.T) {
+	a, calls := newBatchMockAPI(t)
+
+	res, err := a.DeleteMessagesBatched(chatID, []int{3, 1, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(res) != 3 || res[0].MessageID != 1 || res[2].MessageID != 3 || res[0].NewMessageID != 0 {
+		t.Fatalf("unexpected results %+v", res)
+	}
+	if c := calls(); len(c) != 1 || c[0] != "deleteMessages" {
+		t.Fatalf("unexpected calls %v", c)
+	}
+}
+
+func TestDeleteMessagesBatchedNoFallback(t *testing.T) {
+	a, calls := newBatchMockAPI(t)
+
+	res, err := a.DeleteMessagesBatched(chatID, []int{1, 999})
+	if err == nil || !strings.Contains(err.Error(), "kicked") {
+		t.Fatalf("expected the error of the request, got %v", err)
+	}
+
+	if len(res) != 2 || res[0].Err != err || res[1].Err != err {
+		t.Fatalf("unexpected results %+v", res)
+	}
+	if c := calls(); len(c) != 1 || c[0] != "deleteMessages" {
+		t.Fatalf("unexpected calls %v", c)
+	}
+}
+
+func TestCopyMessages(t *testing.T) {
+	a, reqs := newMockAPI(t, `[{"message_id":11},{"message_id":12}]`)
+
+	res, err := a.CopyMessages(chatID, chatID, []int{1, 2}, &CopyMessagesOptions{RemoveCaption: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := <-reqs
+	if req.method != "copyMessages" || req.query.Get("message_ids") != "[1,2]" || req.query.Get("remove_caption") != "true" {
+		t.Fatalf("unexpected request %s %v", req.method, req.query)
+	}
+	if len(res.Result) != 2 || res.Result[1].MessageID != 12 {
+		t.Fatalf("unexpected result %+v", res.Result)
+	}
+}
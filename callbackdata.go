@@ -0,0 +1,262 @@
+/*
+ * Echotron
+ * Copyright (C) 2018-2022 The Echotron Devs
+ *
+ * Echotron is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Echotron is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package echotron
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// inlinePayload separates the route from a payload stored in the callback data itself.
+	inlinePayload = ':'
+	// storedPayload separates the route from the key of a payload saved in a CallbackStore.
+	storedPayload = '#'
+	// callbackSeparators contains the characters that separate the route from the payload.
+	callbackSeparators = ":#"
+	// signatureLength is the length of the base64 encoded truncated HMAC of the signed callback data.
+	signatureLength = 11
+)
+
+var (
+	// ErrInvalidCallbackData is returned when decoding callback data not produced by a CallbackCodec.
+	ErrInvalidCallbackData = errors.New("invalid callback data")
+	// ErrInvalidSignature is returned when the signature of the callback data doesn't match its content.
+	ErrInvalidSignature = errors.New("invalid callback data signature")
+	// ErrCallbackDataNotFound is returned when the payload referenced by the callback data isn't in the CallbackStore.
+	ErrCallbackDataNotFound = errors.New("callback data not found")
+)
+
+// CallbackStore is the interface used by CallbackCodec to save the payloads
+// that don't fit in the 64 bytes of the callback data.
+type CallbackStore interface {
+	Put(key, value string) error
+	Get(key string) (string, error)
+}
+
+type storedCallback struct {
+	value   string
+	expires time.Time
+}
+
+// callbackSweepInterval is the number of calls to MemoryCallbackStore.Put between two sweeps of the expired payloads.
+const callbackSweepInterval = 1024
+
+// MemoryCallbackStore is a CallbackStore that keeps the payloads in memory.
+type MemoryCallbackStore struct {
+	data map[string]storedCallback
+	ttl  time.Duration
+	puts int
+	mu   sync.Mutex
+}
+
+// NewMemoryCallbackStore returns a new instance of the MemoryCallbackStore object.
+// The payloads are discarded after the given ttl, a ttl of 0 keeps them forever.
+func NewMemoryCallbackStore(ttl time.Duration) *MemoryCallbackStore {
+	return &MemoryCallbackStore{data: make(map[string]storedCallback), ttl: ttl}
+}
+
+// Put saves the value with the given key.
+// The expired payloads are discarded when they're looked up and, periodically, by Put itself.
+func (m *MemoryCallbackStore) Put(key, value string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var sc = storedCallback{value: value}
+	if m.ttl > 0 {
+		now := time.Now()
+		sc.expires = now.Add(m.ttl)
+
+		if m.puts++; m.puts >= callbackSweepInterval {
+			m.puts = 0
+			for k, v := range m.data {
+				if now.After(v.expires) {
+					delete(m.data, k)
+				}
+			}
+		}
+	}
+
+	m.data[key] = sc
+	return nil
+}
+
+// Get returns the value saved with the given key, or ErrCallbackDataNotFound if missing or expired.
+func (m *MemoryCallbackStore) Get(key string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sc, ok := m.data[key]
+	if !ok {
+		return "", ErrCallbackDataNotFound
+	}
+	if !sc.expires.IsZero() && time.Now().After(sc.expires) {
+		delete(m.data, key)
+		return "", ErrCallbackDataNotFound
+	}
+	return sc.value, nil
+}
+
+// CallbackCodec serializes values into the CallbackData of an InlineKeyboardButton and decodes them back
+// from the Data of the resulting CallbackQuery.
+// Each value is encoded as JSON after a route prefix used to tell apart the different kinds of buttons.
+// Payloads exceeding MaxCallbackDataLength bytes are saved in the CallbackStore, if any,
+// and referenced by a short random key.
+// If a non-empty secret is set, the callback data is signed with HMAC-SHA256 to detect tampering.
+type CallbackCodec struct {
+	store  CallbackStore
+	secret []byte
+}
+
+// NewCallbackCodec returns a new instance of the CallbackCodec object.
+// Both store and secret are optional.
+func NewCallbackCodec(store CallbackStore, secret []byte) *CallbackCodec {
+	return &CallbackCodec{store: store, secret: secret}
+}
+
+// Encode returns the callback data made of the route and the JSON encoding of v.
+// The route must not contain the ':' and '#' characters.
+func (c *CallbackCodec) Encode(route string, v interface{}) (string, error) {
+	if strings.ContainsAny(route, callbackSeparators) {
+		return "", fmt.Errorf("%w: route %q contains a reserved character", ErrInvalidCallbackData, route)
+	}
+
+	jsn, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	data := c.join(route, inlinePayload, string(jsn))
+	if len(data) <= MaxCallbackDataLength {
+		return data, nil
+	}
+
+	if c.store == nil {
+		return "", fmt.Errorf("%w: %d bytes, at most %d allowed", ErrCallbackDataTooLong, len(data), MaxCallbackDataLength)
+	}
+
+	key, err := randomKey()
+	if err != nil {
+		return "", err
+	}
+
+	if err = c.store.Put(key, string(jsn)); err != nil {
+		return "", err
+	}
+
+	data = c.join(route, storedPayload, key)
+	if len(data) > MaxCallbackDataLength {
+		return "", fmt.Errorf("%w: route %q is too long", ErrCallbackDataTooLong, route)
+	}
+	return data, nil
+}
+
+// Button returns an InlineKeyboardButton with the given text and the callback data encoded by Encode.
+func (c *CallbackCodec) Button(text, route string, v interface{}) (InlineKeyboardButton, error) {
+	data, err := c.Encode(route, v)
+	if err != nil {
+		return InlineKeyboardButton{}, err
+	}
+	return CallbackButton(text, data), nil
+}
+
+// Decode verifies the callback data, unmarshals its payload into v and returns its route.
+// If v is nil, only the route is returned.
+func (c *CallbackCodec) Decode(data string, v interface{}) (string, error) {
+	i := strings.IndexAny(data, callbackSeparators)
+	if i == -1 {
+		return "", ErrInvalidCallbackData
+	}
+
+	route, kind, payload := data[:i], data[i], data[i+1:]
+
+	if len(c.secret) > 0 {
+		if len(payload) < signatureLength {
+			return "", ErrInvalidSignature
+		}
+
+		sig := payload[:signatureLength]
+		payload = payload[signatureLength:]
+		if !hmac.Equal([]byte(sig), []byte(c.sign(route, kind, payload))) {
+			return "", ErrInvalidSignature
+		}
+	}
+
+	if v == nil {
+		return route, nil
+	}
+
+	if kind == storedPayload {
+		if c.store == nil {
+			return "", ErrCallbackDataNotFound
+		}
+
+		var err error
+		if payload, err = c.store.Get(payload); err != nil {
+			return "", err
+		}
+	}
+
+	if err := json.Unmarshal([]byte(payload), v); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidCallbackData, err)
+	}
+	return route, nil
+}
+
+// CallbackRoute returns the route of callback data produced by a CallbackCodec without verifying it.
+func CallbackRoute(data string) string {
+	if i := strings.IndexAny(data, callbackSeparators); i != -1 {
+		return data[:i]
+	}
+	return data
+}
+
+// join assembles the callback data, signing it if a secret is set.
+func (c *CallbackCodec) join(route string, kind byte, payload string) string {
+	if len(c.secret) > 0 {
+		return route + string(kind) + c.sign(route, kind, payload) + payload
+	}
+	return route + string(kind) + payload
+}
+
+// sign returns the truncated base64 encoded HMAC-SHA256 of the callback data.
+func (c *CallbackCodec) sign(route string, kind byte, payload string) string {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write([]byte(route))
+	mac.Write([]byte{kind})
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:8])
+}
+
+// randomKey returns a random key used to save payloads in a CallbackStore.
+func randomKey() (string, error) {
+	var b = make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,120 @@
+package echotron
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+type itemCallback struct {
+	ID   int    `json:"id"`
+	Note string `json:"n,omitempty"`
+}
+
+func TestCallbackCodec(t *testing.T) {
+	c := NewCallbackCodec(nil, nil)
+
+	data, err := c.Encode("item", itemCallback{ID: 42})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data != `item:{"id":42}` {
+		t.Fatalf("unexpected callback data %q", data)
+	}
+
+	var v itemCallback
+	if route, err := c.Decode(data, &v); err != nil || route != "item" || v.ID != 42 {
+		t.Fatalf("unexpected decoded data %q %+v %v", route, v, err)
+	}
+
+	if CallbackRoute(data) != "item" {
+		t.Fatalf("unexpected route %q", CallbackRoute(data))
+	}
+
+	if _, err = c.Encode("item", itemCallback{Note: strings.Repeat("x", 64)}); !errors.Is(err, ErrCallbackDataTooLong) {
+		t.Fatalf("expected ErrCallbackDataTooLong, got %v", err)
+	}
+
+	if _, err = c.Encode("a:b", nil); !errors.Is(err, ErrInvalidCallbackData) {
+		t.Fatalf("expected ErrInvalidCallbackData, got %v", err)
+	}
+}
+
+func TestCallbackCodecStore(t *testing.T) {
+	c := NewCallbackCodec(NewMemoryCallbackStore(0), []byte("secret"))
+	long := itemCallback{ID: 1, Note: strings.Repeat("x", 100)}
+
+	data, err := c.Encode("item", long)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) > MaxCallbackDataLength || !strings.HasPrefix(data, "item#") {
+		t.Fatalf("unexpected callback data %q", data)
+	}
+
+	var v itemCallback
+	if _, err = c.Decode(data, &v); err != nil || v != long {
+		t.Fatalf("unexpected decoded data %+v %v", v, err)
+	}
+
+	if _, err = NewCallbackCodec(NewMemoryCallbackStore(0), []byte("secret")).Decode(data, &v); !errors.Is(err, ErrCallbackDataNotFound) {
+		t.Fatalf("expected ErrCallbackDataNotFound, got %v", err)
+	}
+}
+
+func TestCallbackCodecSignature(t *testing.T) {
+	c := NewCallbackCodec(nil, []byte("secret"))
+
+	data, err := c.Encode("item", itemCallback{ID: 42})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var v itemCallback
+	if _, err = c.Decode(data, &v); err != nil || v.ID != 42 {
+		t.Fatalf("unexpected decoded data %+v %v", v, err)
+	}
+
+	tampered := strings.Replace(data, "42", "43", 1)
+	if _, err = c.Decode(tampered, &v); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("expected ErrInvalidSignature, got %v", err)
+	}
+
+	if _, err = NewCallbackCodec(nil, []byte("other")).Decode(data, &v); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("expected ErrInvalidSignature, got %v", err)
+	}
+}
+
+func TestMemoryCallbackStoreExpiration(t *testing.T) {
+	s := NewMemoryCallbackStore(time.Millisecond)
+	s.Put("old", "value")
+	time.Sleep(2 * time.Millisecond)
+
+	if _, err := s.Get("old"); !errors.Is(err, ErrCallbackDataNotFound) {
+		t.Fatalf("expected ErrCallbackDataNotFound, got %v", err)
+	}
+	if _, ok := s.data["old"]; ok {
+		t.Fatal("expired payload not discarded on Get")
+	}
+
+	s.Put("stale", "value")
+	time.Sleep(2 * time.Millisecond)
+	for i := 0; i < callbackSweepInterval; i++ {
+		s.Put(strconv.Itoa(i), "value")
+	}
+	if _, ok := s.data["stale"]; ok {
+		t.Fatal("expired payload not swept by Put")
+	}
+}
+
+func TestCallbackCodecEmptySecret(t *testing.T) {
+	data, err := NewCallbackCodec(nil, []byte{}).Encode("item", itemCallback{ID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data != `item:{"id":1}` {
+		t.Fatalf("unexpected callback data %q", data)
+	}
+}
@@ -0,0 +1,165 @@
+/*
+ * Echotron
+ * Copyright (C) 2018-2022 The Echotron Devs
+ *
+ * Echotron is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Echotron is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package echotron
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// MessageIDOptions returns the MessageIDOptions identifying the message the callback query originated from,
+// either a message sent by the bot or an inline message sent via the bot.
+func (c *CallbackQuery) MessageIDOptions() MessageIDOptions {
+	if c.InlineMessageID != "" || c.Message == nil || c.Message.Chat == nil {
+		return NewInlineMessageID(c.InlineMessageID)
+	}
+	return NewMessageID(c.Message.Chat.ID, c.Message.ID)
+}
+
+// CallbackContext is passed to a CallbackHandler and contains the callback query being handled.
+type CallbackContext struct {
+	API   API
+	Query *CallbackQuery
+	// Match contains the submatches of the regular expression that matched the callback data, if any.
+	Match []string
+
+	answered bool
+	mu       sync.Mutex
+}
+
+// Answer answers the callback query.
+// Once called, the CallbackRouter won't answer the query on its own.
+func (c *CallbackContext) Answer(opts *CallbackQueryOptions) (APIResponseBool, error) {
+	c.mu.Lock()
+	c.answered = true
+	c.mu.Unlock()
+	return c.API.AnswerCallbackQuery(c.Query.ID, opts)
+}
+
+// Answered returns true if the callback query has already been answered.
+func (c *CallbackContext) Answered() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.answered
+}
+
+// CallbackHandler is the function called by a CallbackRouter to handle a callback query.
+// If the handler doesn't answer the query itself, the router answers it with the returned options, which can be nil.
+type CallbackHandler func(c *CallbackContext) (*CallbackQueryOptions, error)
+
+type callbackRoute struct {
+	match   func(data string) ([]string, bool)
+	handler CallbackHandler
+}
+
+// CallbackRouter dispatches the callback queries to the handler registered for their data and
+// makes sure each query gets answered, so that the client stops showing the progress indicator.
+// The routes are tried in the order they were registered.
+type CallbackRouter struct {
+	api      API
+	routes   []callbackRoute
+	notFound CallbackHandler
+	mu       sync.RWMutex
+}
+
+// NewCallbackRouter returns a new instance of the CallbackRouter object.
+func NewCallbackRouter(api API) *CallbackRouter {
+	return &CallbackRouter{api: api}
+}
+
+func (r *CallbackRouter) add(match func(string) ([]string, bool), h CallbackHandler) {
+	r.mu.Lock()
+	r.routes = append(r.routes, callbackRoute{match, h})
+	r.mu.Unlock()
+}
+
+// Handle registers the handler for the callback queries whose data starts with prefix.
+func (r *CallbackRouter) Handle(prefix string, h CallbackHandler) {
+	r.add(func(data string) ([]string, bool) {
+		return nil, strings.HasPrefix(data, prefix)
+	}, h)
+}
+
+// HandleRoute registers the handler for the callback queries whose data has been encoded
+// by a CallbackCodec with the given route.
+func (r *CallbackRouter) HandleRoute(route string, h CallbackHandler) {
+	r.add(func(data string) ([]string, bool) {
+		return nil, CallbackRoute(data) == route
+	}, h)
+}
+
+// HandleRegexp registers the handler for the callback queries whose data matches the regular expression.
+// The submatches are passed to the handler in the Match field of the CallbackContext.
+func (r *CallbackRouter) HandleRegexp(re *regexp.Regexp, h CallbackHandler) {
+	r.add(func(data string) ([]string, bool) {
+		m := re.FindStringSubmatch(data)
+		return m, m != nil
+	}, h)
+}
+
+// NotFound registers the handler for the callback queries not matched by any other route.
+func (r *CallbackRouter) NotFound(h CallbackHandler) {
+	r.mu.Lock()
+	r.notFound = h
+	r.mu.Unlock()
+}
+
+// Route dispatches the callback query to the matching handler and answers it if the handler didn't.
+// It returns false if no handler matched the query, in which case the query isn't answered.
+func (r *CallbackRouter) Route(q *CallbackQuery) (bool, error) {
+	var (
+		h   CallbackHandler
+		ctx = &CallbackContext{API: r.api, Query: q}
+	)
+
+	r.mu.RLock()
+	for _, route := range r.routes {
+		if m, ok := route.match(q.Data); ok {
+			h = route.handler
+			ctx.Match = m
+			break
+		}
+	}
+	if h == nil {
+		h = r.notFound
+	}
+	r.mu.RUnlock()
+
+	if h == nil {
+		return false, nil
+	}
+
+	opts, err := h(ctx)
+	if !ctx.Answered() {
+		if _, aerr := ctx.Answer(opts); err == nil {
+			err = aerr
+		}
+	}
+
+	return true, err
+}
+
+// Update routes the callback query contained in the update, if any, and returns true if the update has been used.
+func (r *CallbackRouter) Update(u *Update) (bool, error) {
+	if u.CallbackQuery == nil {
+		return false, nil
+	}
+	return r.Route(u.CallbackQuery)
+}
@@ -0,0 +1,87 @@
+package echotron
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestCallbackRouter(t *testing.T) {
+	a, reqs := newMockAPI(t, "true")
+	r := NewCallbackRouter(a)
+
+	r.Handle("menu:", func(c *CallbackContext) (*CallbackQueryOptions, error) {
+		return &CallbackQueryOptions{Text: "menu", ShowAlert: true}, nil
+	})
+	r.HandleRegexp(regexp.MustCompile(`^page:(\d+)$`), func(c *CallbackContext) (*CallbackQueryOptions, error) {
+		if c.Match[1] != "3" {
+			t.Errorf("unexpected match %v", c.Match)
+		}
+		return nil, errors.New("failed")
+	})
+	r.HandleRoute("item", func(c *CallbackContext) (*CallbackQueryOptions, error) {
+		_, err := c.Answer(&CallbackQueryOptions{Text: "manual"})
+		return &CallbackQueryOptions{Text: "ignored"}, err
+	})
+
+	if ok, err := r.Route(&CallbackQuery{ID: "1", Data: "menu:main"}); !ok || err != nil {
+		t.Fatalf("unexpected result %t %v", ok, err)
+	}
+	if req := <-reqs; req.query.Get("callback_query_id") != "1" || req.query.Get("text") != "menu" || req.query.Get("show_alert") != "true" {
+		t.Fatalf("unexpected request %+v", req)
+	}
+
+	// Queries are answered even when the handler fails.
+	if ok, err := r.Route(&CallbackQuery{ID: "2", Data: "page:3", InlineMessageID: "inline"}); !ok || err == nil {
+		t.Fatalf("unexpected result %t %v", ok, err)
+	}
+	if req := <-reqs; req.query.Get("callback_query_id") != "2" || req.query.Get("text") != "" {
+		t.Fatalf("unexpected request %+v", req)
+	}
+
+	if ok, err := r.Update(&Update{CallbackQuery: &CallbackQuery{ID: "3", Data: `item:{"id":1}`}}); !ok || err != nil {
+		t.Fatalf("unexpected result %t %v", ok, err)
+	}
+	if req := <-reqs; req.query.Get("text") != "manual" {
+		t.Fatalf("unexpected request %+v", req)
+	}
+
+	if ok, _ := r.Route(&CallbackQuery{ID: "4", Data: "unknown"}); ok {
+		t.Fatal("unexpected match")
+	}
+
+	select {
+	case req := <-reqs:
+		t.Fatalf("unexpected request %+v", req)
+	default:
+	}
+}
+
+func TestCallbackQueryMessageIDOptions(t *testing.T) {
+	q := CallbackQuery{Message: &Message{ID: 5, Chat: &Chat{ID: 42}}}
+	if opts := q.MessageIDOptions(); opts != NewMessageID(42, 5) {
+		t.Fatalf("unexpected options %+v", opts)
+	}
+
+	q = CallbackQuery{InlineMessageID: "inline"}
+	if opts := q.MessageIDOptions(); opts != NewInlineMessageID("inline") {
+		t.Fatalf("unexpected options %+v", opts)
+	}
+}
+
+func TestInlineCallbackRouting(t *testing.T) {
+	updates := make(chan int64, 1)
+	d := NewDispatcher("token", func(chatID int64) Bot { return chatRecorder{chatID, updates} })
+
+	d.updates <- &Update{CallbackQuery: &CallbackQuery{ID: "1", From: &User{ID: 7}, InlineMessageID: "inline"}}
+
+	select {
+	case id := <-updates:
+		if id != 7 {
+			t.Fatalf("expected chat 7, got %d", id)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("update not dispatched")
+	}
+}
@@ -20,13 +20,15 @@ package echotron
 
 import (
 	"compress/gzip"
+	"container/list"
 	"encoding/json"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
 	"net/url"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // Bot is the interface that must be implemented by your definition of
@@ -40,16 +42,46 @@ type Bot interface {
 // encountered before.
 type NewBotFn func(chatId int64) Bot
 
+// NewThreadBotFn is called every time echotron receives an update from a forum topic never
+// encountered before, if the sessions are keyed by chat and topic with SetThreadSessions.
+type NewThreadBotFn func(chatID int64, threadID int) Bot
+
+// threadKey identifies the session of a forum topic.
+type threadKey struct {
+	chatID   int64
+	threadID int
+}
+
+// pollEntry associates a poll to the chat it has been sent to.
+type pollEntry struct {
+	pollID string
+	chatID int64
+}
+
+// DefaultMaxPolls is the default maximum number of polls the Dispatcher keeps associated to their chat.
+const DefaultMaxPolls = 10000
+
 // The Dispatcher passes the updates from the Telegram Bot API to the Bot instance
 // associated with each chatID. When a new chat ID is found, the provided function
 // of type NewBotFn will be called.
 type Dispatcher struct {
-	api        API
-	sessionMap map[int64]Bot
-	newBot     NewBotFn
-	updates    chan *Update
-	handler    http.Handler
-	mu         sync.Mutex
+	api         API
+	sessionMap  map[int64]Bot
+	threadMap   map[threadKey]Bot
+	pollIndex   map[string]*list.Element
+	pollOrder   *list.List
+	maxPolls    int
+	mediaGroups map[mediaGroupKey]*mediaGroup
+	groupWindow time.Duration
+	newBot      NewBotFn
+	newThread   NewThreadBotFn
+	updates     chan *Update
+	handler     http.Handler
+	recorder    *recorder
+	logger      Logger
+	instr       Instrumentation
+	pending     int32
+	mu          sync.Mutex
 }
 
 // NewDispatcher returns a new instance of the Dispatcher object.
@@ -57,11 +89,17 @@ type Dispatcher struct {
 // If a new chat ID is found, newBotFn will be called first.
 func NewDispatcher(token string, newBotFn NewBotFn) *Dispatcher {
 	d := &Dispatcher{
-		api:        NewAPI(token),
-		sessionMap: make(map[int64]Bot),
-		newBot:     newBotFn,
-		updates:    make(chan *Update),
-		handler:    nil,
+		api:         NewAPI(token),
+		sessionMap:  make(map[int64]Bot),
+		threadMap:   make(map[threadKey]Bot),
+		pollIndex:   make(map[string]*list.Element),
+		pollOrder:   list.New(),
+		maxPolls:    DefaultMaxPolls,
+		mediaGroups: make(map[mediaGroupKey]*mediaGroup),
+		newBot:      newBotFn,
+		updates:     make(chan *Update),
+		handler:     nil,
+		logger:      NewStdLogger(nil, false),
 	}
 	go d.listen()
 	return d
@@ -84,6 +122,152 @@ func (d *Dispatcher) AddSession(chatID int64) {
 	d.mu.Unlock()
 }
 
+// SetThreadSessions makes the Dispatcher key the sessions of forum supergroups by chat and topic,
+// so that the updates of each topic are passed to a dedicated Bot instance created with newThreadFn.
+// The updates which don't belong to a topic are still passed to the Bot instance of their chat.
+// A nil newThreadFn, the default, disables the keying by topic.
+func (d *Dispatcher) SetThreadSessions(newThreadFn NewThreadBotFn) {
+	d.mu.Lock()
+	d.newThread = newThreadFn
+	d.mu.Unlock()
+}
+
+// DelThreadSession deletes the Bot instance associated with the given forum topic.
+func (d *Dispatcher) DelThreadSession(chatID int64, threadID int) {
+	d.mu.Lock()
+	delete(d.threadMap, threadKey{chatID, threadID})
+	d.mu.Unlock()
+}
+
+// AddPoll associates the poll with the given ID to the chat it has been sent to,
+// so that the Poll and PollAnswer updates about it are passed to the Bot instance of that chat.
+// Polls contained in the messages received by the Dispatcher are associated automatically,
+// as well as the ones sent with the SendPoll method of a PollTracker linked with SetDispatcher,
+// while the ones sent by the bot with API.SendPoll have to be added with the ID found in the response.
+func (d *Dispatcher) AddPoll(pollID string, chatID int64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if el, ok := d.pollIndex[pollID]; ok {
+		el.Value = pollEntry{pollID, chatID}
+		d.pollOrder.MoveToBack(el)
+		return
+	}
+
+	d.pollIndex[pollID] = d.pollOrder.PushBack(pollEntry{pollID, chatID})
+	d.trimPolls()
+}
+
+// DelPoll removes the association between the poll with the given ID and its chat.
+func (d *Dispatcher) DelPoll(pollID string) {
+	d.mu.Lock()
+	if el, ok := d.pollIndex[pollID]; ok {
+		d.pollOrder.Remove(el)
+		delete(d.pollIndex, pollID)
+	}
+	d.mu.Unlock()
+}
+
+// SetMaxPolls sets the maximum number of polls associated to their chat, DefaultMaxPolls by default.
+// Once the limit is reached the least recently added polls are forgotten, and the updates about them
+// are handled like the ones about unknown polls.
+// A value lower than or equal to 0 removes the limit.
+func (d *Dispatcher) SetMaxPolls(n int) {
+	d.mu.Lock()
+	d.maxPolls = n
+	d.trimPolls()
+	d.mu.Unlock()
+}
+
+// trimPolls forgets the oldest polls exceeding the limit, d.mu must be held.
+func (d *Dispatcher) trimPolls() {
+	for d.maxPolls > 0 && d.pollOrder.Len() > d.maxPolls {
+		el := d.pollOrder.Front()
+		d.pollOrder.Remove(el)
+		delete(d.pollIndex, el.Value.(pollEntry).pollID)
+	}
+}
+
+// pollChat returns the ID of the chat the poll with the given ID has been sent to, if known.
+func (d *Dispatcher) pollChat(pollID string) (chatID int64, ok bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if el, isIn := d.pollIndex[pollID]; isIn {
+		return el.Value.(pollEntry).chatID, true
+	}
+	return
+}
+
+// indexPoll associates the poll contained in the message, if any, to the chat of the message.
+func (d *Dispatcher) indexPoll(msg *Message) {
+	if msg.Poll != nil && msg.Chat != nil {
+		d.AddPoll(msg.Poll.ID, msg.Chat.ID)
+	}
+}
+
+// SetLogger sets the Logger used by the Dispatcher and by its underlying API.
+// By default the Dispatcher logs to the standard logger of the log package,
+// a nil Logger silences it completely.
+func (d *Dispatcher) SetLogger(l Logger) {
+	if l == nil {
+		l = nopLogger{}
+	}
+	d.mu.Lock()
+	d.logger = l
+	d.api.SetLogger(l)
+	d.mu.Unlock()
+}
+
+// SetInstrumentation sets the Instrumentation whose hooks are called around each call
+// to the Update method of the bots and around each request sent by the underlying API.
+// The API objects created by the bots themselves need to be instrumented separately.
+// If the Instrumentation also implements QueueInstrumentation it's given access to the number of pending updates.
+func (d *Dispatcher) SetInstrumentation(i Instrumentation) {
+	d.mu.Lock()
+	d.instr = i
+	d.api.SetInstrumentation(i)
+	d.mu.Unlock()
+
+	if q, ok := i.(QueueInstrumentation); ok {
+		q.ObservePendingUpdates(d.pendingUpdates)
+	}
+}
+
+// client returns a copy of the underlying API, so that it can be used while SetLogger
+// or SetInstrumentation are changing it.
+func (d *Dispatcher) client() API {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.api
+}
+
+// log returns the Logger of the Dispatcher.
+func (d *Dispatcher) log() Logger {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.logger
+}
+
+// instrumentation returns the Instrumentation of the Dispatcher, if any.
+func (d *Dispatcher) instrumentation() Instrumentation {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.instr
+}
+
+// enqueue passes the update to the listening goroutine, keeping track of the updates
+// which have been received but not dispatched yet.
+func (d *Dispatcher) enqueue(u *Update) {
+	atomic.AddInt32(&d.pending, 1)
+	d.updates <- u
+}
+
+// pendingUpdates returns the number of updates which have been received but not dispatched yet.
+func (d *Dispatcher) pendingUpdates() int {
+	return int(atomic.LoadInt32(&d.pending))
+}
+
 // Poll is a wrapper function for PollOptions.
 func (d *Dispatcher) Poll() error {
 	return d.PollOptions(true, &UpdateOptions{Timeout: 120})
@@ -103,7 +287,7 @@ func (d *Dispatcher) PollOptions(dropPendingUpdates bool, opts *UpdateOptions) e
 	}
 
 	// deletes webhook if present to run in long polling mode
-	response, err := d.api.DeleteWebhook(dropPendingUpdates)
+	response, err := d.client().DeleteWebhook(dropPendingUpdates)
 	if err != nil {
 		return err
 	} else if !response.Ok {
@@ -116,19 +300,28 @@ func (d *Dispatcher) PollOptions(dropPendingUpdates bool, opts *UpdateOptions) e
 		}
 
 		opts.Offset = lastUpdateID + 1
-		response, err := d.api.GetUpdates(opts)
+		response, err := d.client().getRawUpdates(opts)
 
 		if err != nil {
 			return err
 		} else if response.Ok {
-			if !dropPendingUpdates || !firstRun {
-				for _, u := range response.Result {
-					d.updates <- u
+			for _, raw := range response.Result {
+				var u Update
+
+				// A partially decoded update still carries its ID, which is needed to move past it.
+				err := json.Unmarshal(raw, &u)
+				if u.ID > lastUpdateID {
+					lastUpdateID = u.ID
+				}
+				if err != nil {
+					d.log().Error("could not unmarshal update", "error", err)
+					continue
 				}
-			}
 
-			if l := len(response.Result); l > 0 {
-				lastUpdateID = response.Result[l-1].ID
+				if !dropPendingUpdates || !firstRun {
+					d.record(raw)
+					d.enqueue(&u)
+				}
 			}
 		}
 
@@ -142,40 +335,157 @@ func (d *Dispatcher) PollOptions(dropPendingUpdates bool, opts *UpdateOptions) e
 	}
 }
 
+// instance returns the Bot instance of the given chat, creating it if needed.
+// The Bot is created without holding the lock, so that newBot can call the methods of the Dispatcher.
 func (d *Dispatcher) instance(chatID int64) Bot {
+	d.mu.Lock()
 	bot, ok := d.sessionMap[chatID]
-	if !ok {
-		bot = d.newBot(chatID)
-		d.mu.Lock()
-		d.sessionMap[chatID] = bot
-		d.mu.Unlock()
+	d.mu.Unlock()
+
+	if ok {
+		return bot
 	}
+
+	bot = d.newBot(chatID)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	// Another goroutine may have added the session in the meantime.
+	if b, ok := d.sessionMap[chatID]; ok {
+		return b
+	}
+	d.sessionMap[chatID] = bot
 	return bot
 }
 
+// session returns the Bot instance the update has to be passed to, which is the one of its
+// forum topic if the sessions are keyed by topic, or the one of its chat otherwise.
+func (d *Dispatcher) session(chatID int64, u *Update) Bot {
+	if threadID := updateThreadID(u); threadID != 0 {
+		if bot, ok := d.threadInstance(chatID, threadID); ok {
+			return bot
+		}
+	}
+	return d.instance(chatID)
+}
+
+// threadInstance returns the Bot instance of the given forum topic,
+// or false if the sessions aren't keyed by topic.
+// Like instance, the Bot is created without holding the lock.
+func (d *Dispatcher) threadInstance(chatID int64, threadID int) (Bot, bool) {
+	key := threadKey{chatID, threadID}
+
+	d.mu.Lock()
+	newThread := d.newThread
+	bot, ok := d.threadMap[key]
+	d.mu.Unlock()
+
+	if newThread == nil {
+		return nil, false
+	}
+	if ok {
+		return bot, true
+	}
+
+	bot = newThread(chatID, threadID)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if b, ok := d.threadMap[key]; ok {
+		return b, true
+	}
+	d.threadMap[key] = bot
+	return bot, true
+}
+
+// updateThreadID returns the ID of the forum topic the update belongs to, or 0 if none.
+func updateThreadID(u *Update) int {
+	var msg *Message
+
+	switch {
+	case u.Message != nil:
+		msg = u.Message
+	case u.EditedMessage != nil:
+		msg = u.EditedMessage
+	case u.CallbackQuery != nil:
+		msg = u.CallbackQuery.Message
+	}
+
+	if msg == nil || !msg.IsTopicMessage {
+		return 0
+	}
+	return msg.MessageThreadID
+}
+
 func (d *Dispatcher) listen() {
 	for update := range d.updates {
 		var chatID int64
 
+		atomic.AddInt32(&d.pending, -1)
+
 		if update.Message != nil {
 			chatID = update.Message.Chat.ID
+			d.indexPoll(update.Message)
 		} else if update.EditedMessage != nil {
 			chatID = update.EditedMessage.Chat.ID
 		} else if update.ChannelPost != nil {
 			chatID = update.ChannelPost.Chat.ID
+			d.indexPoll(update.ChannelPost)
 		} else if update.EditedChannelPost != nil {
 			chatID = update.EditedChannelPost.Chat.ID
+		} else if update.MessageReaction != nil {
+			chatID = update.MessageReaction.Chat.ID
+		} else if update.MessageReactionCount != nil {
+			chatID = update.MessageReactionCount.Chat.ID
 		} else if update.CallbackQuery != nil {
-			chatID = update.CallbackQuery.Message.Chat.ID
+			// Callback queries from inline messages carry no message, so they're passed to the private chat with the user.
+			if update.CallbackQuery.Message != nil {
+				chatID = update.CallbackQuery.Message.Chat.ID
+			} else {
+				chatID = update.CallbackQuery.From.ID
+			}
 		} else if update.InlineQuery != nil {
 			chatID = update.InlineQuery.From.ID
+		} else if update.ShippingQuery != nil {
+			chatID = update.ShippingQuery.From.ID
+		} else if update.PreCheckoutQuery != nil {
+			chatID = update.PreCheckoutQuery.From.ID
+		} else if update.Poll != nil {
+			id, ok := d.pollChat(update.Poll.ID)
+			if !ok {
+				continue
+			}
+			chatID = id
+		} else if update.PollAnswer != nil {
+			// Answers to unknown polls are passed to the private chat with the voter.
+			if id, ok := d.pollChat(update.PollAnswer.PollID); ok {
+				chatID = id
+			} else if update.PollAnswer.User != nil {
+				chatID = update.PollAnswer.User.ID
+			} else {
+				continue
+			}
 		} else {
 			continue
 		}
 
-		bot := d.instance(chatID)
-		go bot.Update(update)
+		if d.bufferMediaGroup(chatID, update) {
+			continue
+		}
+
+		bot := d.session(chatID, update)
+		go d.runUpdate(bot, chatID, update)
+	}
+}
+
+// runUpdate calls the Update method of the bot surrounded by the Instrumentation hooks, if any.
+func (d *Dispatcher) runUpdate(bot Bot, chatID int64, update *Update) {
+	if instr := d.instrumentation(); instr != nil {
+		defer instr.StartUpdate(chatID, update)()
 	}
+	bot.Update(update)
 }
 
 // ListenWebhook is a wrapper function for ListenWebhookOptions.
@@ -197,13 +507,13 @@ func (d *Dispatcher) ListenWebhookOptions(webhookURL string, dropPendingUpdates
 	}
 
 	whURL := fmt.Sprintf("%s%s", u.Hostname(), u.EscapedPath())
-	log.Printf("setting webhook for %s\n", whURL)
-	response, err = d.api.SetWebhook(whURL, dropPendingUpdates, opts)
+	d.log().Info("setting webhook", "url", whURL)
+	response, err = d.client().SetWebhook(whURL, dropPendingUpdates, opts)
 	if err != nil {
 		return err
 	} else if response.Ok {
 		http.HandleFunc(u.EscapedPath(), d.HandleWebhook)
-		log.Printf("listening on :%s\n", u.Port())
+		d.log().Info("listening for webhook updates", "port", u.Port())
 		return http.ListenAndServe(fmt.Sprintf(":%s", u.Port()), d.handler)
 	}
 
@@ -224,29 +534,30 @@ func (d *Dispatcher) HandleWebhook(w http.ResponseWriter, r *http.Request) {
 	case "gzip":
 		reader, err := gzip.NewReader(r.Body)
 		if err != nil {
-			log.Println(err)
+			d.log().Error("could not decompress webhook body", "error", err)
 			return
 		}
 		defer reader.Close()
 		if j, err := io.ReadAll(reader); err == nil {
 			jsn = j
 		} else {
-			log.Println(err)
+			d.log().Error("could not read webhook body", "error", err)
 		}
 
 	default:
 		if j, err := io.ReadAll(r.Body); err == nil {
 			jsn = j
 		} else {
-			log.Println(err)
+			d.log().Error("could not read webhook body", "error", err)
 		}
 	}
 
 	var update Update
 	if err := json.Unmarshal(jsn, &update); err != nil {
-		log.Println(err)
+		d.log().Error("could not unmarshal webhook update", "error", err)
 		return
 	}
 
-	d.updates <- &update
+	d.record(jsn)
+	d.enqueue(&update)
 }
@@ -47,5 +47,140 @@ func TestPoll(_ *testing.T) {
 	dsp.updates <- &Update{EditedChannelPost: &Message{Chat: &Chat{ID: 0}}}
 	dsp.updates <- &Update{CallbackQuery: &CallbackQuery{Message: &Message{Chat: &Chat{ID: 0}}}}
 	dsp.updates <- &Update{InlineQuery: &InlineQuery{From: &User{ID: 0}}}
+	dsp.updates <- &Update{Poll: &Poll{ID: "0"}}
+	dsp.updates <- &Update{PollAnswer: &PollAnswer{PollID: "0", User: &User{ID: 0}}}
 	time.Sleep(time.Second)
 }
+
+type chatRecorder struct {
+	chatID  int64
+	updates chan int64
+}
+
+func (c chatRecorder) Update(_ *Update) {
+	c.updates <- c.chatID
+}
+
+func TestPollRouting(t *testing.T) {
+	updates := make(chan int64, 1)
+	d := NewDispatcher("token", func(chatID int64) Bot { return chatRecorder{chatID, updates} })
+
+	expect := func(chatID int64) {
+		select {
+		case id := <-updates:
+			if id != chatID {
+				t.Fatalf("expected chat %d, got %d", chatID, id)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("update for chat %d not dispatched", chatID)
+		}
+	}
+
+	d.updates <- &Update{Message: &Message{Chat: &Chat{ID: -100}, Poll: &Poll{ID: "group"}}}
+	expect(-100)
+
+	d.AddPoll("sent", -200)
+
+	d.updates <- &Update{PollAnswer: &PollAnswer{PollID: "group", User: &User{ID: 1}}}
+	expect(-100)
+	d.updates <- &Update{Poll: &Poll{ID: "sent"}}
+	expect(-200)
+	d.updates <- &Update{PollAnswer: &PollAnswer{PollID: "unknown", User: &User{ID: 1}}}
+	expect(1)
+}
+
+type threadRecorder struct {
+	threadID int
+	updates  chan int
+}
+
+func (r threadRecorder) Update(_ *Update) {
+	r.updates <- r.threadID
+}
+
+func TestThreadSessions(t *testing.T) {
+	updates := make(chan int, 1)
+	d := NewDispatcher("token", func(_ int64) Bot { return threadRecorder{0, updates} })
+
+	expect := func(threadID int) {
+		select {
+		case id := <-updates:
+			if id != threadID {
+				t.Fatalf("expected thread %d, got %d", threadID, id)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("update for thread %d not dispatched", threadID)
+		}
+	}
+
+	topic := &Message{Chat: &Chat{ID: -100}, MessageThreadID: 42, IsTopicMessage: true}
+
+	d.updates <- &Update{Message: topic}
+	expect(0)
+
+	d.SetThreadSessions(func(_ int64, threadID int) Bot { return threadRecorder{threadID, updates} })
+
+	d.updates <- &Update{Message: topic}
+	expect(42)
+	d.updates <- &Update{CallbackQuery: &CallbackQuery{From: &User{ID: 1}, Message: topic}}
+	expect(42)
+	d.updates <- &Update{Message: &Message{Chat: &Chat{ID: -100}, MessageThreadID: 7}}
+	expect(0)
+
+	if len(d.threadMap) != 1 {
+		t.Fatalf("expected 1 thread session, got %d", len(d.threadMap))
+	}
+	d.DelThreadSession(-100, 42)
+	if len(d.threadMap) != 0 {
+		t.Fatal("could not delete thread session")
+	}
+}
+
+func TestMaxPolls(t *testing.T) {
+	d := NewDispatcher("token", func(_ int64) Bot { return nil })
+	d.SetMaxPolls(2)
+
+	d.AddPoll("a", 1)
+	d.AddPoll("b", 2)
+	d.AddPoll("a", 3)
+	d.AddPoll("c", 4)
+
+	if _, ok := d.pollChat("b"); ok {
+		t.Fatal("oldest poll not forgotten")
+	}
+	if chatID, ok := d.pollChat("a"); !ok || chatID != 3 {
+		t.Fatalf("unexpected chat %d for poll a", chatID)
+	}
+
+	d.SetMaxPolls(1)
+	if _, ok := d.pollChat("a"); ok {
+		t.Fatal("poll a not forgotten after lowering the limit")
+	}
+	if chatID, ok := d.pollChat("c"); !ok || chatID != 4 {
+		t.Fatalf("unexpected chat %d for poll c", chatID)
+	}
+}
+
+func TestNewBotCallsDispatcher(t *testing.T) {
+	var (
+		d       *Dispatcher
+		updates = make(chan int64, 1)
+	)
+
+	// The constructor calls back into the Dispatcher, which must not deadlock.
+	d = NewDispatcher("token", func(chatID int64) Bot {
+		d.AddPoll("poll", chatID)
+		d.SetLogger(nil)
+		return chatRecorder{chatID, updates}
+	})
+	d.updates <- &Update{Message: &Message{Chat: &Chat{ID: 1}}}
+
+	select {
+	case id := <-updates:
+		if id != 1 {
+			t.Fatalf("expected chat 1, got %d", id)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("update not dispatched")
+	}
+}
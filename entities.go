@@ -0,0 +1,258 @@
+/*
+ * Echotron
+ * Copyright (C) 2018-2022 The Echotron Devs
+ *
+ * Echotron is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Echotron is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package echotron
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf16"
+)
+
+// EntityText returns the portion of text covered by the entity.
+// The Offset and Length fields of MessageEntity are expressed in UTF-16 code units,
+// so slicing the UTF-8 encoded Go string directly with them gives wrong results
+// as soon as the text contains characters outside of the Basic Multilingual Plane, such as emojis.
+func EntityText(text string, e *MessageEntity) string {
+	return utf16Slice(utf16.Encode([]rune(text)), e.Offset, e.Length)
+}
+
+// FormatEntities renders the text together with its entities in the MarkdownV2 or HTML parse mode,
+// e.g. to re-post or archive a received message while keeping its formatting.
+// Entities that don't change the appearance of the text, like hashtags or bot commands,
+// are rendered as plain text.
+// Any other parse mode returns the text as it is.
+func FormatEntities(text string, entities []*MessageEntity, mode ParseMode) string {
+	if mode != MarkdownV2 && mode != HTML {
+		return text
+	}
+
+	var (
+		sb     strings.Builder
+		units  = utf16.Encode([]rune(text))
+		sorted = make([]*MessageEntity, 0, len(entities))
+		stack  []*MessageEntity
+		pos    int
+		next   int
+	)
+
+	// Entities are clamped to the text, so that they're all closed by the end of it.
+	for _, e := range entities {
+		c := *e
+		if c.Offset < 0 {
+			c.Length += c.Offset
+			c.Offset = 0
+		}
+		if c.Offset+c.Length > len(units) {
+			c.Length = len(units) - c.Offset
+		}
+
+		if open, _ := entityTags(c, mode); open != "" && c.Length > 0 {
+			sorted = append(sorted, &c)
+		}
+	}
+
+	// Outer entities must be opened before the inner ones starting at the same offset.
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Offset == sorted[j].Offset {
+			return sorted[i].Length > sorted[j].Length
+		}
+		return sorted[i].Offset < sorted[j].Offset
+	})
+
+	for {
+		stack = closeEntities(&sb, stack, pos, mode)
+
+		for ; next < len(sorted) && sorted[next].Offset <= pos; next++ {
+			open, _ := entityTags(*sorted[next], mode)
+			writeTag(&sb, open, mode)
+			stack = append(stack, sorted[next])
+		}
+
+		if pos >= len(units) {
+			break
+		}
+
+		stop := len(units)
+		if next < len(sorted) && sorted[next].Offset < stop {
+			stop = sorted[next].Offset
+		}
+		for _, e := range stack {
+			if end := e.Offset + e.Length; end < stop {
+				stop = end
+			}
+		}
+
+		var typ MessageEntityType
+		for _, e := range stack {
+			if e.Type == CodeEntity || e.Type == PreEntity {
+				typ = e.Type
+			}
+		}
+
+		sb.WriteString(escapeEntityText(string(utf16.Decode(units[pos:stop])), typ, mode))
+		pos = stop
+	}
+
+	for i := len(stack) - 1; i >= 0; i-- {
+		_, close := entityTags(*stack[i], mode)
+		writeTag(&sb, close, mode)
+	}
+
+	return sb.String()
+}
+
+// closeEntities closes all the entities in the stack that end at or before pos and returns the new stack.
+// Entities opened after one that has to be closed are closed too and reopened right after,
+// so that the resulting tags are always properly nested.
+// Pre blocks are the exception, since reopening one would start a new block:
+// the rest of their text is rendered outside of them.
+func closeEntities(sb *strings.Builder, stack []*MessageEntity, pos int, mode ParseMode) []*MessageEntity {
+	var first = -1
+
+	for i, e := range stack {
+		if e.Offset+e.Length <= pos {
+			first = i
+			break
+		}
+	}
+
+	if first == -1 {
+		return stack
+	}
+
+	for i := len(stack) - 1; i >= first; i-- {
+		_, close := entityTags(*stack[i], mode)
+		writeTag(sb, close, mode)
+	}
+
+	var reopen = stack[first+1:]
+	stack = stack[:first:first]
+
+	for _, e := range reopen {
+		if e.Offset+e.Length > pos && e.Type != PreEntity {
+			open, _ := entityTags(*e, mode)
+			writeTag(sb, open, mode)
+			stack = append(stack, e)
+		}
+	}
+
+	return stack
+}
+
+// utf16Slice returns the string made of length UTF-16 code units of units starting from offset,
+// clamped to the bounds of units.
+func utf16Slice(units []uint16, offset, length int) string {
+	if offset < 0 {
+		offset = 0
+	}
+	if offset > len(units) {
+		offset = len(units)
+	}
+
+	end := offset + length
+	if end > len(units) {
+		end = len(units)
+	}
+	if end < offset {
+		end = offset
+	}
+
+	return string(utf16.Decode(units[offset:end]))
+}
+
+// content returns the text or the caption of the message together with their entities.
+func (m *Message) content() (string, []*MessageEntity) {
+	if m.Text != "" {
+		return m.Text, m.Entities
+	}
+	return m.Caption, m.CaptionEntities
+}
+
+// EntityText returns the portion of the text or the caption of the message covered by the entity.
+func (m *Message) EntityText(e *MessageEntity) string {
+	text, _ := m.content()
+	return EntityText(text, e)
+}
+
+// EntitiesOfType returns the entities of the given type contained in the text or the caption of the message.
+func (m *Message) EntitiesOfType(t MessageEntityType) (ret []*MessageEntity) {
+	_, entities := m.content()
+
+	for _, e := range entities {
+		if e.Type == t {
+			ret = append(ret, e)
+		}
+	}
+
+	return
+}
+
+// EntityTexts returns the text covered by each entity of the given type contained in the text or the caption of the message.
+func (m *Message) EntityTexts(t MessageEntityType) (ret []string) {
+	text, entities := m.content()
+	units := utf16.Encode([]rune(text))
+
+	for _, e := range entities {
+		if e.Type == t {
+			ret = append(ret, utf16Slice(units, e.Offset, e.Length))
+		}
+	}
+
+	return
+}
+
+// Commands returns the bot commands contained in the message, e.g. "/start" or "/start@mybot".
+func (m *Message) Commands() []string {
+	return m.EntityTexts(BotCommandEntity)
+}
+
+// Mentions returns the usernames mentioned in the message, including the leading '@'.
+func (m *Message) Mentions() []string {
+	return m.EntityTexts(MentionEntity)
+}
+
+// Hashtags returns the hashtags contained in the message, including the leading '#'.
+func (m *Message) Hashtags() []string {
+	return m.EntityTexts(HashtagEntity)
+}
+
+// URLs returns the URLs contained in the message, both the ones written in the text
+// and the ones hidden behind a text link.
+func (m *Message) URLs() (ret []string) {
+	text, entities := m.content()
+	units := utf16.Encode([]rune(text))
+
+	for _, e := range entities {
+		switch e.Type {
+		case UrlEntity:
+			ret = append(ret, utf16Slice(units, e.Offset, e.Length))
+		case TextLinkEntity:
+			ret = append(ret, e.URL)
+		}
+	}
+
+	return
+}
+
+// Format renders the text or the caption of the message in the MarkdownV2 or HTML parse mode
+// preserving its formatting.
+func (m *Message) Format(mode ParseMode) string {
+	text, entities := m.content()
+	return FormatEntities(text, entities, mode)
+}
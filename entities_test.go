@@ -0,0 +1,105 @@
+package echotron
+
+import (
+	"reflect"
+	"testing"
+)
+
+var entitiesMessage = &Message{
+	Text: "😀 /start@bot #go 🎉 @user https://example.com",
+	Entities: []*MessageEntity{
+		{Type: BotCommandEntity, Offset: 3, Length: 10},
+		{Type: HashtagEntity, Offset: 14, Length: 3},
+		{Type: MentionEntity, Offset: 21, Length: 5},
+		{Type: UrlEntity, Offset: 27, Length: 19},
+	},
+}
+
+func TestEntityText(t *testing.T) {
+	if got := entitiesMessage.EntityText(entitiesMessage.Entities[2]); got != "@user" {
+		t.Fatalf("unexpected entity text %q", got)
+	}
+
+	if got := EntityText("abc", &MessageEntity{Offset: 2, Length: 10}); got != "c" {
+		t.Fatalf("out of bounds entity not clamped: %q", got)
+	}
+}
+
+func TestEntityHelpers(t *testing.T) {
+	if got := entitiesMessage.Commands(); !reflect.DeepEqual(got, []string{"/start@bot"}) {
+		t.Fatalf("unexpected commands %v", got)
+	}
+
+	if got := entitiesMessage.Hashtags(); !reflect.DeepEqual(got, []string{"#go"}) {
+		t.Fatalf("unexpected hashtags %v", got)
+	}
+
+	if got := entitiesMessage.Mentions(); !reflect.DeepEqual(got, []string{"@user"}) {
+		t.Fatalf("unexpected mentions %v", got)
+	}
+
+	if got := entitiesMessage.URLs(); !reflect.DeepEqual(got, []string{"https://example.com"}) {
+		t.Fatalf("unexpected URLs %v", got)
+	}
+
+	if n := len(entitiesMessage.EntitiesOfType(BoldEntity)); n != 0 {
+		t.Fatalf("unexpected bold entities: %d", n)
+	}
+}
+
+func TestFormatEntities(t *testing.T) {
+	// Nested and overlapping entities, with an emoji before them.
+	text := "🎉 bold italic code!"
+	entities := []*MessageEntity{
+		{Type: BoldEntity, Offset: 3, Length: 11},
+		{Type: ItalicEntity, Offset: 8, Length: 11},
+		{Type: CodeEntity, Offset: 15, Length: 4},
+	}
+
+	if got := FormatEntities(text, entities, HTML); got != "🎉 <b>bold <i>italic</i></b><i> <code>code</code></i>!" {
+		t.Fatalf("unexpected HTML %q", got)
+	}
+
+	if got := FormatEntities(text, entities, MarkdownV2); got != "🎉 *bold _italic_*_ `code`_\\!" {
+		t.Fatalf("unexpected MarkdownV2 %q", got)
+	}
+
+	msg := &Message{
+		Caption:         "see docs",
+		CaptionEntities: []*MessageEntity{{Type: TextLinkEntity, Offset: 4, Length: 4, URL: "https://example.com"}},
+	}
+	if got := msg.Format(MarkdownV2); got != "see [docs](https://example.com)" {
+		t.Fatalf("unexpected MarkdownV2 %q", got)
+	}
+}
+
+func TestFormatEntitiesOutOfBounds(t *testing.T) {
+	entities := []*MessageEntity{
+		{Type: BoldEntity, Offset: 0, Length: 20},
+		{Type: ItalicEntity, Offset: 3, Length: 10},
+		{Type: CodeEntity, Offset: 8, Length: 2},
+	}
+
+	if got := FormatEntities("hello", entities, HTML); got != "<b>hel<i>lo</i></b>" {
+		t.Fatalf("unexpected HTML %q", got)
+	}
+
+	if got := FormatEntities("hello", entities, MarkdownV2); got != "*hel_lo_*" {
+		t.Fatalf("unexpected MarkdownV2 %q", got)
+	}
+}
+
+func TestFormatEntitiesOverlappingPre(t *testing.T) {
+	entities := []*MessageEntity{
+		{Type: BoldEntity, Offset: 0, Length: 4},
+		{Type: PreEntity, Offset: 2, Length: 4, Language: "go"},
+	}
+
+	if got := FormatEntities("abcdefgh", entities, MarkdownV2); got != "*ab```go\ncd\n```*efgh" {
+		t.Fatalf("unexpected MarkdownV2 %q", got)
+	}
+
+	if got := FormatEntities("abcdefgh", entities, HTML); got != `<b>ab<pre><code class="language-go">cd</code></pre></b>efgh` {
+		t.Fatalf("unexpected HTML %q", got)
+	}
+}
@@ -0,0 +1,327 @@
+/*
+ * Echotron
+ * Copyright (C) 2018-2022 The Echotron Devs
+ *
+ * Echotron is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Echotron is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package echotron
+
+import (
+	"fmt"
+	"strings"
+)
+
+var (
+	markdownV2Replacer = strings.NewReplacer(
+		`\`, `\\`, "_", `\_`, "*", `\*`, "[", `\[`, "]", `\]`, "(", `\(`, ")", `\)`,
+		"~", `\~`, "`", "\\`", ">", `\>`, "#", `\#`, "+", `\+`, "-", `\-`,
+		"=", `\=`, "|", `\|`, "{", `\{`, "}", `\}`, ".", `\.`, "!", `\!`,
+	)
+	markdownV2CodeReplacer = strings.NewReplacer(`\`, `\\`, "`", "\\`")
+	markdownV2URLReplacer  = strings.NewReplacer(`\`, `\\`, ")", `\)`)
+	markdownReplacer       = strings.NewReplacer("_", `\_`, "*", `\*`, "`", "\\`", "[", `\[`)
+	htmlReplacer           = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+)
+
+// EscapeMarkdownV2 escapes all the reserved characters of the MarkdownV2 parse mode in s.
+func EscapeMarkdownV2(s string) string {
+	return markdownV2Replacer.Replace(s)
+}
+
+// EscapeMarkdownV2Code escapes s to be used inside a MarkdownV2 code or pre entity,
+// where only the '`' and '\' characters must be escaped.
+func EscapeMarkdownV2Code(s string) string {
+	return markdownV2CodeReplacer.Replace(s)
+}
+
+// EscapeMarkdownV2URL escapes s to be used as the URL of a MarkdownV2 inline link,
+// where only the ')' and '\' characters must be escaped.
+func EscapeMarkdownV2URL(s string) string {
+	return markdownV2URLReplacer.Replace(s)
+}
+
+// EscapeMarkdown escapes all the reserved characters of the legacy Markdown parse mode in s.
+func EscapeMarkdown(s string) string {
+	return markdownReplacer.Replace(s)
+}
+
+// EscapeHTML escapes all the reserved characters of the HTML parse mode in s.
+func EscapeHTML(s string) string {
+	return htmlReplacer.Replace(s)
+}
+
+// Escape escapes s according to the given parse mode.
+func Escape(s string, mode ParseMode) string {
+	switch mode {
+	case MarkdownV2:
+		return EscapeMarkdownV2(s)
+	case HTML:
+		return EscapeHTML(s)
+	case Markdown:
+		return EscapeMarkdown(s)
+	default:
+		return s
+	}
+}
+
+// textSegment is a piece of text formatted with at most one entity.
+type textSegment struct {
+	text   string
+	entity MessageEntity
+}
+
+// TextBuilder is used to build formatted text that can be rendered either
+// as MarkdownV2, HTML or legacy Markdown, or as plain text with a list of MessageEntity.
+// The user-supplied text is always escaped, so it's safe to pass it as it is.
+type TextBuilder struct {
+	segments []textSegment
+}
+
+// NewTextBuilder returns a new instance of the TextBuilder object.
+func NewTextBuilder() *TextBuilder {
+	return &TextBuilder{}
+}
+
+func (b *TextBuilder) add(text string, entity MessageEntity) *TextBuilder {
+	b.segments = append(b.segments, textSegment{text, entity})
+	return b
+}
+
+// Text appends unformatted text.
+func (b *TextBuilder) Text(s string) *TextBuilder {
+	return b.add(s, MessageEntity{})
+}
+
+// Textf appends unformatted text formatted according to the format specifier.
+func (b *TextBuilder) Textf(format string, a ...interface{}) *TextBuilder {
+	return b.Text(fmt.Sprintf(format, a...))
+}
+
+// Bold appends bold text.
+func (b *TextBuilder) Bold(s string) *TextBuilder {
+	return b.add(s, MessageEntity{Type: BoldEntity})
+}
+
+// Italic appends italic text.
+func (b *TextBuilder) Italic(s string) *TextBuilder {
+	return b.add(s, MessageEntity{Type: ItalicEntity})
+}
+
+// Underline appends underlined text.
+func (b *TextBuilder) Underline(s string) *TextBuilder {
+	return b.add(s, MessageEntity{Type: UnderlineEntity})
+}
+
+// Strikethrough appends strikethrough text.
+func (b *TextBuilder) Strikethrough(s string) *TextBuilder {
+	return b.add(s, MessageEntity{Type: StrikethroughEntity})
+}
+
+// Spoiler appends text hidden behind a spoiler.
+func (b *TextBuilder) Spoiler(s string) *TextBuilder {
+	return b.add(s, MessageEntity{Type: SpoilerEntity})
+}
+
+// Code appends inline fixed-width code.
+func (b *TextBuilder) Code(s string) *TextBuilder {
+	return b.add(s, MessageEntity{Type: CodeEntity})
+}
+
+// Pre appends a pre-formatted fixed-width code block written in the given programming language,
+// which can be empty.
+func (b *TextBuilder) Pre(s, language string) *TextBuilder {
+	return b.add(s, MessageEntity{Type: PreEntity, Language: language})
+}
+
+// Link appends text linking to the given URL.
+func (b *TextBuilder) Link(s, url string) *TextBuilder {
+	return b.add(s, MessageEntity{Type: TextLinkEntity, URL: url})
+}
+
+// Mention appends text mentioning the user with the given ID.
+func (b *TextBuilder) Mention(s string, userID int64) *TextBuilder {
+	return b.add(s, MessageEntity{Type: TextMentionEntity, User: &User{ID: userID}})
+}
+
+// String renders the text according to the given parse mode.
+// Formatting that isn't supported by the legacy Markdown parse mode is rendered as plain text,
+// while an empty parse mode renders the plain text without any formatting.
+func (b *TextBuilder) String(mode ParseMode) string {
+	var sb strings.Builder
+
+	for _, s := range b.segments {
+		switch mode {
+		case MarkdownV2, HTML:
+			open, close := entityTags(s.entity, mode)
+			writeTag(&sb, open, mode)
+			sb.WriteString(escapeEntityText(s.text, s.entity.Type, mode))
+			writeTag(&sb, close, mode)
+		case Markdown:
+			sb.WriteString(markdownSegment(s))
+		default:
+			sb.WriteString(s.text)
+		}
+	}
+
+	return sb.String()
+}
+
+// Entities returns the plain text together with the list of MessageEntity describing its formatting,
+// ready to be used in the Entities or CaptionEntities fields of the various options.
+func (b *TextBuilder) Entities() (string, []MessageEntity) {
+	var (
+		sb       strings.Builder
+		offset   int
+		entities []MessageEntity
+	)
+
+	for _, s := range b.segments {
+		length := utf16Len(s.text)
+
+		if s.entity.Type != "" && length > 0 {
+			e := s.entity
+			e.Offset = offset
+			e.Length = length
+			entities = append(entities, e)
+		}
+
+		sb.WriteString(s.text)
+		offset += length
+	}
+
+	return sb.String(), entities
+}
+
+// entityTags returns the strings that open and close the entity in the MarkdownV2 or HTML parse mode.
+// Entities that don't change the appearance of the text, like hashtags or bot commands, have no tags.
+func entityTags(e MessageEntity, mode ParseMode) (open, close string) {
+	if mode == HTML {
+		return htmlTags(e)
+	}
+	return markdownV2Tags(e)
+}
+
+func markdownV2Tags(e MessageEntity) (string, string) {
+	switch e.Type {
+	case BoldEntity:
+		return "*", "*"
+	case ItalicEntity:
+		return "_", "_"
+	case UnderlineEntity:
+		return "__", "__"
+	case StrikethroughEntity:
+		return "~", "~"
+	case SpoilerEntity:
+		return "||", "||"
+	case CodeEntity:
+		return "`", "`"
+	case PreEntity:
+		return "```" + e.Language + "\n", "\n```"
+	case TextLinkEntity:
+		return "[", "](" + EscapeMarkdownV2URL(e.URL) + ")"
+	case TextMentionEntity:
+		return "[", "](" + mentionURL(e.User) + ")"
+	default:
+		return "", ""
+	}
+}
+
+func htmlTags(e MessageEntity) (string, string) {
+	switch e.Type {
+	case BoldEntity:
+		return "<b>", "</b>"
+	case ItalicEntity:
+		return "<i>", "</i>"
+	case UnderlineEntity:
+		return "<u>", "</u>"
+	case StrikethroughEntity:
+		return "<s>", "</s>"
+	case SpoilerEntity:
+		return "<tg-spoiler>", "</tg-spoiler>"
+	case CodeEntity:
+		return "<code>", "</code>"
+	case PreEntity:
+		if e.Language != "" {
+			return `<pre><code class="language-` + EscapeHTML(e.Language) + `">`, "</code></pre>"
+		}
+		return "<pre>", "</pre>"
+	case TextLinkEntity:
+		return `<a href="` + EscapeHTML(e.URL) + `">`, "</a>"
+	case TextMentionEntity:
+		return `<a href="` + mentionURL(e.User) + `">`, "</a>"
+	default:
+		return "", ""
+	}
+}
+
+// escapeEntityText escapes text contained in an entity of the given type according to the parse mode.
+func escapeEntityText(s string, t MessageEntityType, mode ParseMode) string {
+	if mode == MarkdownV2 && (t == CodeEntity || t == PreEntity) {
+		return EscapeMarkdownV2Code(s)
+	}
+	return Escape(s, mode)
+}
+
+// writeTag writes a MarkdownV2 or HTML tag to sb.
+func writeTag(sb *strings.Builder, tag string, mode ParseMode) {
+	// Adjacent italic and underline delimiters are ambiguous in MarkdownV2,
+	// Telegram ignores the '\r' character used to separate them.
+	if mode == MarkdownV2 && strings.HasPrefix(tag, "_") && strings.HasSuffix(sb.String(), "_") {
+		sb.WriteByte('\r')
+	}
+	sb.WriteString(tag)
+}
+
+// markdownSegment renders the segment in the legacy Markdown parse mode, which doesn't allow escaping
+// inside entities: the entity is closed before the escaped delimiter and reopened right after.
+func markdownSegment(s textSegment) string {
+	switch s.entity.Type {
+	case BoldEntity:
+		return "*" + strings.ReplaceAll(s.text, "*", "*\\**") + "*"
+	case ItalicEntity:
+		return "_" + strings.ReplaceAll(s.text, "_", "_\\__") + "_"
+	case CodeEntity:
+		return "`" + strings.ReplaceAll(s.text, "`", "`\\``") + "`"
+	case PreEntity:
+		return "```" + s.entity.Language + "\n" + strings.ReplaceAll(s.text, "`", "") + "\n```"
+	case TextLinkEntity:
+		return "[" + strings.ReplaceAll(s.text, "]", "") + "](" + s.entity.URL + ")"
+	case TextMentionEntity:
+		return "[" + strings.ReplaceAll(s.text, "]", "") + "](" + mentionURL(s.entity.User) + ")"
+	default:
+		return EscapeMarkdown(s.text)
+	}
+}
+
+// mentionURL returns the URL used to mention a user in formatted text.
+func mentionURL(u *User) string {
+	if u == nil {
+		return ""
+	}
+	return fmt.Sprintf("tg://user?id=%d", u.ID)
+}
+
+// utf16Len returns the length of s in UTF-16 code units, the unit used by Telegram for
+// the offsets and lengths of the MessageEntity objects.
+func utf16Len(s string) (n int) {
+	for _, r := range s {
+		if r >= 0x10000 {
+			n += 2
+		} else {
+			n++
+		}
+	}
+	return
+}
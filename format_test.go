@@ -0,0 +1,76 @@
+package echotron
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEscapeMarkdownV2(t *testing.T) {
+	if got := EscapeMarkdownV2("1.5 * (2 + 3) = 7.5!"); got != `1\.5 \* \(2 \+ 3\) \= 7\.5\!` {
+		t.Fatalf("unexpected escaped text %q", got)
+	}
+
+	if got := EscapeMarkdownV2Code("a `b` \\ c."); got != "a \\`b\\` \\\\ c." {
+		t.Fatalf("unexpected escaped code %q", got)
+	}
+
+	if got := EscapeMarkdownV2URL("https://example.com/a_(b)"); got != `https://example.com/a_(b\)` {
+		t.Fatalf("unexpected escaped URL %q", got)
+	}
+}
+
+func TestEscapeHTML(t *testing.T) {
+	if got := EscapeHTML(`<a href="x">&</a>`); got != "&lt;a href=&quot;x&quot;&gt;&amp;&lt;/a&gt;" {
+		t.Fatalf("unexpected escaped text %q", got)
+	}
+}
+
+func TestTextBuilder(t *testing.T) {
+	b := NewTextBuilder().
+		Text("Hi ").
+		Bold("v1.0").
+		Text(" ").
+		Italic("a").
+		Underline("b").
+		Text(" ").
+		Pre("x := 1", "go").
+		Text(" ").
+		Link("docs", "https://example.com/(x)").
+		Text(" ").
+		Mention("you", 42)
+
+	mdv2 := "Hi *v1\\.0* _a_\r__b__ ```go\nx := 1\n``` [docs](https://example.com/(x\\)) [you](tg://user?id=42)"
+	if got := b.String(MarkdownV2); got != mdv2 {
+		t.Fatalf("unexpected MarkdownV2 %q", got)
+	}
+
+	html := `Hi <b>v1.0</b> <i>a</i><u>b</u> <pre><code class="language-go">x := 1</code></pre> <a href="https://example.com/(x)">docs</a> <a href="tg://user?id=42">you</a>`
+	if got := b.String(HTML); got != html {
+		t.Fatalf("unexpected HTML %q", got)
+	}
+
+	if got := NewTextBuilder().Bold("a*b").String(Markdown); got != `*a*\**b*` {
+		t.Fatalf("unexpected Markdown %q", got)
+	}
+}
+
+func TestTextBuilderEntities(t *testing.T) {
+	text, entities := NewTextBuilder().
+		Text("😀 ").
+		Bold("bold").
+		Text(" ").
+		Spoiler("secret").
+		Entities()
+
+	if text != "😀 bold secret" {
+		t.Fatalf("unexpected text %q", text)
+	}
+
+	expected := []MessageEntity{
+		{Type: BoldEntity, Offset: 3, Length: 4},
+		{Type: SpoilerEntity, Offset: 8, Length: 6},
+	}
+	if !reflect.DeepEqual(entities, expected) {
+		t.Fatalf("unexpected entities %+v", entities)
+	}
+}
@@ -0,0 +1,393 @@
+/*
+ * Echotron
+ * Copyright (C) 2018-2022 The Echotron Devs
+ *
+ * Echotron is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Echotron is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package echotron
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// ForumTopic represents a forum topic.
+type ForumTopic struct {
+	MessageThreadID   int    `json:"message_thread_id"`
+	Name              string `json:"name"`
+	IconColor         int    `json:"icon_color"`
+	IconCustomEmojiID string `json:"icon_custom_emoji_id,omitempty"`
+}
+
+// ForumTopicCreated represents a service message about a new forum topic created in the chat.
+type ForumTopicCreated struct {
+	Name              string `json:"name"`
+	IconColor         int    `json:"icon_color"`
+	IconCustomEmojiID string `json:"icon_custom_emoji_id,omitempty"`
+}
+
+// ForumTopicEdited represents a service message about an edited forum topic.
+type ForumTopicEdited struct {
+	Name              string  `json:"name,omitempty"`
+	IconCustomEmojiID *string `json:"icon_custom_emoji_id,omitempty"`
+}
+
+// ForumTopicClosed represents a service message about a forum topic closed in the chat.
+// Currently holds no information.
+type ForumTopicClosed struct{}
+
+// ForumTopicReopened represents a service message about a forum topic reopened in the chat.
+// Currently holds no information.
+type ForumTopicReopened struct{}
+
+// GeneralForumTopicHidden represents a service message about the General forum topic hidden in the chat.
+// Currently holds no information.
+type GeneralForumTopicHidden struct{}
+
+// GeneralForumTopicUnhidden represents a service message about the General forum topic unhidden in the chat.
+// Currently holds no information.
+type GeneralForumTopicUnhidden struct{}
+
+// These are all the colors allowed for the icon of a forum topic, in RGB format.
+const (
+	TopicIconBlue   = 0x6FB9F0
+	TopicIconYellow = 0xFFD67E
+	TopicIconViolet = 0xCB86DB
+	TopicIconGreen  = 0x8EEE98
+	TopicIconRose   = 0xFF93B2
+	TopicIconRed    = 0xFB6F5F
+)
+
+// CreateTopicOptions contains the optional parameters used by the CreateForumTopic method.
+type CreateTopicOptions struct {
+	IconColor         int    `query:"icon_color"`
+	IconCustomEmojiID string `query:"icon_custom_emoji_id"`
+}
+
+// EditTopicOptions contains the optional parameters used by the EditForumTopic method.
+// The fields left empty are kept unchanged.
+// Set RemoveIcon to remove the custom emoji used as the icon of the topic, IconCustomEmojiID is ignored.
+type EditTopicOptions struct {
+	Name              string `query:"name"`
+	IconCustomEmojiID string `query:"icon_custom_emoji_id"`
+	RemoveIcon        bool
+}
+
+// CreateForumTopic is used to create a topic in a forum supergroup chat.
+// The bot must be an administrator in the chat for this to work and must have the CanManageTopics administrator rights.
+func (a API) CreateForumTopic(chatID int64, name string, opts *CreateTopicOptions) (res APIResponseForumTopic, err error) {
+	var url = fmt.Sprintf(
+		"%screateForumTopic?chat_id=%d&name=%s&%s",
+		a.base,
+		chatID,
+		encode(name),
+		querify(opts),
+	)
+
+	cnt, err := a.sendGetRequest(url)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(cnt, &res); err != nil {
+		return
+	}
+
+	err = check(res)
+	return
+}
+
+// EditForumTopic is used to edit name and icon of a topic in a forum supergroup chat.
+// The bot must be an administrator in the chat for this to work and must have the CanManageTopics administrator rights,
+// unless it is the creator of the topic.
+func (a API) EditForumTopic(chatID int64, messageThreadID int, opts *EditTopicOptions) (res APIResponseBool, err error) {
+	var vals = scan(opts, url.Values{})
+
+	// An empty icon_custom_emoji_id removes the icon, but querify skips the empty fields.
+	if opts != nil && opts.RemoveIcon {
+		vals.Set("icon_custom_emoji_id", "")
+	}
+
+	var url = fmt.Sprintf(
+		"%seditForumTopic?chat_id=%d&message_thread_id=%d&%s",
+		a.base,
+		chatID,
+		messageThreadID,
+		vals.Encode(),
+	)
+
+	cnt, err := a.sendGetRequest(url)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(cnt, &res); err != nil {
+		return
+	}
+
+	err = check(res)
+	return
+}
+
+// CloseForumTopic is used to close an open topic in a forum supergroup chat.
+// The bot must be an administrator in the chat for this to work and must have the CanManageTopics administrator rights,
+// unless it is the creator of the topic.
+func (a API) CloseForumTopic(chatID int64, messageThreadID int) (res APIResponseBool, err error) {
+	var url = fmt.Sprintf(
+		"%scloseForumTopic?chat_id=%d&message_thread_id=%d",
+		a.base,
+		chatID,
+		messageThreadID,
+	)
+
+	cnt, err := a.sendGetRequest(url)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(cnt, &res); err != nil {
+		return
+	}
+
+	err = check(res)
+	return
+}
+
+// ReopenForumTopic is used to reopen a closed topic in a forum supergroup chat.
+// The bot must be an administrator in the chat for this to work and must have the CanManageTopics administrator rights,
+// unless it is the creator of the topic.
+func (a API) ReopenForumTopic(chatID int64, messageThreadID int) (res APIResponseBool, err error) {
+	var url = fmt.Sprintf(
+		"%sreopenForumTopic?chat_id=%d&message_thread_id=%d",
+		a.base,
+		chatID,
+		messageThreadID,
+	)
+
+	cnt, err := a.sendGetRequest(url)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(cnt, &res); err != nil {
+		return
+	}
+
+	err = check(res)
+	return
+}
+
+// DeleteForumTopic is used to delete a forum topic along with all its messages in a forum supergroup chat.
+// The bot must be an administrator in the chat for this to work and must have the CanDeleteMessages administrator rights.
+func (a API) DeleteForumTopic(chatID int64, messageThreadID int) (res APIResponseBool, err error) {
+	var url = fmt.Sprintf(
+		"%sdeleteForumTopic?chat_id=%d&message_thread_id=%d",
+		a.base,
+		chatID,
+		messageThreadID,
+	)
+
+	cnt, err := a.sendGetRequest(url)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(cnt, &res); err != nil {
+		return
+	}
+
+	err = check(res)
+	return
+}
+
+// UnpinAllForumTopicMessages is used to clear the list of pinned messages in a forum topic.
+// The bot must be an administrator in the chat for this to work and must have the CanPinMessages administrator right in the supergroup.
+func (a API) UnpinAllForumTopicMessages(chatID int64, messageThreadID int) (res APIResponseBool, err error) {
+	var url = fmt.Sprintf(
+		"%sunpinAllForumTopicMessages?chat_id=%d&message_thread_id=%d",
+		a.base,
+		chatID,
+		messageThreadID,
+	)
+
+	cnt, err := a.sendGetRequest(url)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(cnt, &res); err != nil {
+		return
+	}
+
+	err = check(res)
+	return
+}
+
+// UnpinAllGeneralForumTopicMessages is used to clear the list of pinned messages in the 'General' topic of a forum supergroup chat.
+// The bot must be an administrator in the chat for this to work and must have the CanPinMessages administrator rights.
+func (a API) UnpinAllGeneralForumTopicMessages(chatID int64) (res APIResponseBool, err error) {
+	var url = fmt.Sprintf(
+		"%sunpinAllGeneralForumTopicMessages?chat_id=%d",
+		a.base,
+		chatID,
+	)
+
+	cnt, err := a.sendGetRequest(url)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(cnt, &res); err != nil {
+		return
+	}
+
+	err = check(res)
+	return
+}
+
+// EditGeneralForumTopic is used to edit the name of the 'General' topic in a forum supergroup chat.
+// The bot must be an administrator in the chat for this to work and must have the CanManageTopics administrator rights.
+func (a API) EditGeneralForumTopic(chatID int64, name string) (res APIResponseBool, err error) {
+	var url = fmt.Sprintf(
+		"%seditGeneralForumTopic?chat_id=%d&name=%s",
+		a.base,
+		chatID,
+		encode(name),
+	)
+
+	cnt, err := a.sendGetRequest(url)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(cnt, &res); err != nil {
+		return
+	}
+
+	err = check(res)
+	return
+}
+
+// CloseGeneralForumTopic is used to close an open 'General' topic in a forum supergroup chat.
+// The bot must be an administrator in the chat for this to work and must have the CanManageTopics administrator rights.
+func (a API) CloseGeneralForumTopic(chatID int64) (res APIResponseBool, err error) {
+	var url = fmt.Sprintf(
+		"%scloseGeneralForumTopic?chat_id=%d",
+		a.base,
+		chatID,
+	)
+
+	cnt, err := a.sendGetRequest(url)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(cnt, &res); err != nil {
+		return
+	}
+
+	err = check(res)
+	return
+}
+
+// ReopenGeneralForumTopic is used to reopen a closed 'General' topic in a forum supergroup chat.
+// The bot must be an administrator in the chat for this to work and must have the CanManageTopics administrator rights.
+// The topic will be automatically unhidden if it was hidden.
+func (a API) ReopenGeneralForumTopic(chatID int64) (res APIResponseBool, err error) {
+	var url = fmt.Sprintf(
+		"%sreopenGeneralForumTopic?chat_id=%d",
+		a.base,
+		chatID,
+	)
+
+	cnt, err := a.sendGetRequest(url)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(cnt, &res); err != nil {
+		return
+	}
+
+	err = check(res)
+	return
+}
+
+// HideGeneralForumTopic is used to hide the 'General' topic in a forum supergroup chat.
+// The bot must be an administrator in the chat for this to work and must have the CanManageTopics administrator rights.
+// The topic will be automatically closed if it was open.
+func (a API) HideGeneralForumTopic(chatID int64) (res APIResponseBool, err error) {
+	var url = fmt.Sprintf(
+		"%shideGeneralForumTopic?chat_id=%d",
+		a.base,
+		chatID,
+	)
+
+	cnt, err := a.sendGetRequest(url)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(cnt, &res); err != nil {
+		return
+	}
+
+	err = check(res)
+	return
+}
+
+// UnhideGeneralForumTopic is used to unhide the 'General' topic in a forum supergroup chat.
+// The bot must be an administrator in the chat for this to work and must have the CanManageTopics administrator rights.
+func (a API) UnhideGeneralForumTopic(chatID int64) (res APIResponseBool, err error) {
+	var url = fmt.Sprintf(
+		"%sunhideGeneralForumTopic?chat_id=%d",
+		a.base,
+		chatID,
+	)
+
+	cnt, err := a.sendGetRequest(url)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(cnt, &res); err != nil {
+		return
+	}
+
+	err = check(res)
+	return
+}
+
+// GetForumTopicIconStickers is used to get custom emoji stickers,
+// which can be used as a forum topic icon by any user.
+func (a API) GetForumTopicIconStickers() (res APIResponseStickers, err error) {
+	var url = fmt.Sprintf(
+		"%sgetForumTopicIconStickers",
+		a.base,
+	)
+
+	cnt, err := a.sendGetRequest(url)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(cnt, &res); err != nil {
+		return
+	}
+
+	err = check(res)
+	return
+}
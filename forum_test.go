@@ -0,0 +1,151 @@
+package echotron
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCreateForumTopic(t *testing.T) {
+	a, reqs := newMockAPI(t, `{"message_thread_id":42,"name":"Echotron","icon_color":7322096}`)
+
+	res, err := a.CreateForumTopic(-100, "Echotron", &CreateTopicOptions{IconColor: TopicIconBlue})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := <-reqs
+	if req.method != "createForumTopic" || req.query.Get("name") != "Echotron" || req.query.Get("icon_color") != "7322096" {
+		t.Fatalf("unexpected request %s %v", req.method, req.query)
+	}
+	if res.Result == nil || res.Result.MessageThreadID != 42 || res.Result.IconColor != TopicIconBlue {
+		t.Fatalf("unexpected result %+v", res.Result)
+	}
+}
+
+func TestForumTopicActions(t *testing.T) {
+	a, reqs := newMockAPI(t, "true")
+
+	for method, call := range map[string]func(int64, int) (APIResponseBool, error){
+		"closeForumTopic":            a.CloseForumTopic,
+		"reopenForumTopic":           a.ReopenForumTopic,
+		"deleteForumTopic":           a.DeleteForumTopic,
+		"unpinAllForumTopicMessages": a.UnpinAllForumTopicMessages,
+	} {
+		if _, err := call(-100, 42); err != nil {
+			t.Fatal(err)
+		}
+
+		req := <-reqs
+		if req.method != method || req.query.Get("chat_id") != "-100" || req.query.Get("message_thread_id") != "42" {
+			t.Fatalf("unexpected request %s %v", req.method, req.query)
+		}
+	}
+
+	if _, err := a.EditForumTopic(-100, 42, &EditTopicOptions{Name: "Renamed"}); err != nil {
+		t.Fatal(err)
+	}
+	if req := <-reqs; req.method != "editForumTopic" || req.query.Get("name") != "Renamed" || req.query.Get("message_thread_id") != "42" {
+		t.Fatalf("unexpected request %s %v", req.method, req.query)
+	}
+
+	if _, err := a.EditForumTopic(-100, 42, &EditTopicOptions{IconCustomEmojiID: "123", RemoveIcon: true}); err != nil {
+		t.Fatal(err)
+	}
+	if req := <-reqs; req.query.Get("icon_custom_emoji_id") != "" || req.query["icon_custom_emoji_id"] == nil || req.query.Get("name") != "" {
+		t.Fatalf("unexpected request %s %v", req.method, req.query)
+	}
+}
+
+func TestGeneralForumTopicActions(t *testing.T) {
+	a, reqs := newMockAPI(t, "true")
+
+	for method, call := range map[string]func(int64) (APIResponseBool, error){
+		"closeGeneralForumTopic":            a.CloseGeneralForumTopic,
+		"reopenGeneralForumTopic":           a.ReopenGeneralForumTopic,
+		"hideGeneralForumTopic":             a.HideGeneralForumTopic,
+		"unhideGeneralForumTopic":           a.UnhideGeneralForumTopic,
+		"unpinAllGeneralForumTopicMessages": a.UnpinAllGeneralForumTopicMessages,
+	} {
+		if _, err := call(-100); err != nil {
+			t.Fatal(err)
+		}
+
+		if req := <-reqs; req.method != method || req.query.Get("chat_id") != "-100" {
+			t.Fatalf("unexpected request %s %v", req.method, req.query)
+		}
+	}
+
+	if _, err := a.EditGeneralForumTopic(-100, "General"); err != nil {
+		t.Fatal(err)
+	}
+	if req := <-reqs; req.method != "editGeneralForumTopic" || req.query.Get("name") != "General" {
+		t.Fatalf("unexpected request %s %v", req.method, req.query)
+	}
+}
+
+func TestGetForumTopicIconStickers(t *testing.T) {
+	a, _ := newMockAPI(t, `[{"file_id":"a","file_unique_id":"b","width":100,"height":100,"is_animated":true}]`)
+
+	res, err := a.GetForumTopicIconStickers()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(res.Result) != 1 || res.Result[0].FileID != "a" {
+		t.Fatalf("unexpected result %+v", res.Result)
+	}
+}
+
+func TestMessageThreadIDOption(t *testing.T) {
+	a, reqs := newMockAPI(t, `{"message_id":1,"message_thread_id":42,"is_topic_message":true}`)
+
+	res, err := a.SendMessage("hello", -100, &MessageOptions{MessageThreadID: 42})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if req := <-reqs; req.query.Get("message_thread_id") != "42" {
+		t.Fatalf("missing message_thread_id in %v", req.query)
+	}
+	if !res.Result.IsTopicMessage || res.Result.MessageThreadID != 42 {
+		t.Fatalf("unexpected result %+v", res.Result)
+	}
+}
+
+func TestSendChatActionThread(t *testing.T) {
+	a, reqs := newMockAPI(t, "true")
+
+	if _, err := a.SendChatAction(Typing, -100, &ChatActionOptions{MessageThreadID: 42}); err != nil {
+		t.Fatal(err)
+	}
+
+	if req := <-reqs; req.method != "sendChatAction" || req.query.Get("message_thread_id") != "42" || req.query.Get("action") != "typing" {
+		t.Fatalf("unexpected request %s %v", req.method, req.query)
+	}
+}
+
+func TestForumServiceMessages(t *testing.T) {
+	var msg Message
+
+	err := json.Unmarshal([]byte(`{
+		"message_id": 1,
+		"message_thread_id": 42,
+		"is_topic_message": true,
+		"chat": {"id": -100, "type": "supergroup", "is_forum": true},
+		"forum_topic_created": {"name": "Echotron", "icon_color": 16766590}
+	}`), &msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !msg.Chat.IsForum || msg.ForumTopicCreated == nil || msg.ForumTopicCreated.IconColor != TopicIconYellow {
+		t.Fatalf("unexpected message %+v", msg)
+	}
+
+	if err = json.Unmarshal([]byte(`{"message_id":2,"forum_topic_closed":{}}`), &msg); err != nil {
+		t.Fatal(err)
+	}
+	if msg.ForumTopicClosed == nil {
+		t.Fatal("missing forum_topic_closed")
+	}
+}
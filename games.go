@@ -59,7 +59,7 @@ func (a API) SendGame(gameShortName string, chatID int64, opts *BaseOptions) (re
 		querify(opts),
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -83,7 +83,7 @@ func (a API) SetGameScore(userID int64, score int, msgID MessageIDOptions, opts
 		querify(opts),
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -105,7 +105,7 @@ func (a API) GetGameHighScores(userID int64, opts MessageIDOptions) (res APIResp
 		querify(opts),
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -81,7 +81,7 @@ func readFile(im InputFile) (content []byte, path string, err error) {
 	return
 }
 
-func sendFile(file, thumb InputFile, url, fileType string) (res []byte, err error) {
+func (a API) sendFile(file, thumb InputFile, url, fileType string) (res []byte, err error) {
 	var cnt []content
 
 	if file.id != "" {
@@ -99,14 +99,14 @@ func sendFile(file, thumb InputFile, url, fileType string) (res []byte, err erro
 	}
 
 	if len(cnt) > 0 {
-		res, err = sendPostRequest(url, cnt...)
+		res, err = a.sendPostRequest(url, cnt...)
 	} else {
-		res, err = sendGetRequest(url)
+		res, err = a.sendGetRequest(url)
 	}
 	return
 }
 
-func sendMediaFiles(url string, isSingleFile bool, files ...InputMedia) (res []byte, err error) {
+func (a API) sendMediaFiles(url string, isSingleFile bool, files ...InputMedia) (res []byte, err error) {
 	var (
 		med []mediaEnvelope
 		cnt []content
@@ -144,9 +144,9 @@ func sendMediaFiles(url string, isSingleFile bool, files ...InputMedia) (res []b
 	url = fmt.Sprintf("%s&media=%s", url, jsn)
 
 	if len(cnt) > 0 {
-		return sendPostRequest(url, cnt...)
+		return a.sendPostRequest(url, cnt...)
 	} else {
-		return sendGetRequest(url)
+		return a.sendGetRequest(url)
 	}
 }
 
@@ -468,10 +468,11 @@ type InputMessageContent interface {
 
 // InputTextMessageContent represents the content of a text message to be sent as the result of an inline query.
 type InputTextMessageContent struct {
-	MessageText           string           `json:"message_text"`
-	ParseMode             string           `json:"parse_mode,omitempty"`
-	Entities              []*MessageEntity `json:"entities,omitempty"`
-	DisableWebPagePreview bool             `json:"disable_web_page_preview,omitempty"`
+	MessageText           string              `json:"message_text"`
+	ParseMode             string              `json:"parse_mode,omitempty"`
+	Entities              []*MessageEntity    `json:"entities,omitempty"`
+	DisableWebPagePreview bool                `json:"disable_web_page_preview,omitempty"`
+	LinkPreviewOptions    *LinkPreviewOptions `json:"link_preview_options,omitempty"`
 }
 
 // ImplementsInputMessageContent is used to implement the InputMessageContent interface.
@@ -516,6 +517,33 @@ type InputContactMessageContent struct {
 // ImplementsInputMessageContent is used to implement the InputMessageContent interface.
 func (i InputContactMessageContent) ImplementsInputMessageContent() {}
 
+// InputInvoiceMessageContent represents the content of an invoice message to be sent as the result of an inline query.
+type InputInvoiceMessageContent struct {
+	Title                     string         `json:"title"`
+	Description               string         `json:"description"`
+	Payload                   string         `json:"payload"`
+	ProviderToken             string         `json:"provider_token"`
+	Currency                  string         `json:"currency"`
+	Prices                    []LabeledPrice `json:"prices"`
+	MaxTipAmount              int            `json:"max_tip_amount,omitempty"`
+	SuggestedTipAmounts       []int          `json:"suggested_tip_amounts,omitempty"`
+	ProviderData              string         `json:"provider_data,omitempty"`
+	PhotoURL                  string         `json:"photo_url,omitempty"`
+	PhotoSize                 int            `json:"photo_size,omitempty"`
+	PhotoWidth                int            `json:"photo_width,omitempty"`
+	PhotoHeight               int            `json:"photo_height,omitempty"`
+	NeedName                  bool           `json:"need_name,omitempty"`
+	NeedPhoneNumber           bool           `json:"need_phone_number,omitempty"`
+	NeedEmail                 bool           `json:"need_email,omitempty"`
+	NeedShippingAddress       bool           `json:"need_shipping_address,omitempty"`
+	SendPhoneNumberToProvider bool           `json:"send_phone_number_to_provider,omitempty"`
+	SendEmailToProvider       bool           `json:"send_email_to_provider,omitempty"`
+	IsFlexible                bool           `json:"is_flexible,omitempty"`
+}
+
+// ImplementsInputMessageContent is used to implement the InputMessageContent interface.
+func (i InputInvoiceMessageContent) ImplementsInputMessageContent() {}
+
 // InlineQueryOptions is a custom type which contains the various options required by the AnswerInlineQuery method.
 type InlineQueryOptions struct {
 	CacheTime         int    `query:"cache_time"`
@@ -526,18 +554,27 @@ type InlineQueryOptions struct {
 }
 
 // AnswerInlineQuery is used to send answers to an inline query.
+// The Type field of the results is filled automatically according to their concrete type when empty,
+// and the results are checked with ValidateInlineResults before being sent.
 func (a API) AnswerInlineQuery(inlineQueryID string, results []InlineQueryResult, opts *InlineQueryOptions) (res APIResponseBase, err error) {
-	jsn, _ := json.Marshal(results)
+	if results, err = prepareInlineResults(results); err != nil {
+		return
+	}
+
+	jsn, err := json.Marshal(results)
+	if err != nil {
+		return
+	}
 
 	var url = fmt.Sprintf(
 		"%sanswerInlineQuery?inline_query_id=%s&results=%s&%s",
 		a.base,
 		inlineQueryID,
-		jsn,
+		encode(string(jsn)),
 		querify(opts),
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -121,3 +121,8 @@ func TestInputContactMessageContentImplementsInputMessageContent(_ *testing.T) {
 	i := InputContactMessageContent{}
 	i.ImplementsInputMessageContent()
 }
+
+func TestInputInvoiceMessageContentImplementsInputMessageContent(_ *testing.T) {
+	i := InputInvoiceMessageContent{}
+	i.ImplementsInputMessageContent()
+}
@@ -0,0 +1,166 @@
+/*
+ * Echotron
+ * Copyright (C) 2018-2022 The Echotron Devs
+ *
+ * Echotron is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Echotron is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package echotron
+
+import (
+	"strconv"
+	"sync"
+	"time"
+)
+
+// InlineResultSource returns at most limit results for the inline query starting from offset.
+// Returning fewer than limit results means that there are no more results.
+type InlineResultSource func(q *InlineQuery, offset, limit int) ([]InlineQueryResult, error)
+
+type inlinePage struct {
+	results []InlineQueryResult
+	next    string
+	expires time.Time
+}
+
+// InlinePager answers the inline queries a page at a time, handling the Offset of the queries
+// and the NextOffset of the answers so that clients load the following pages while scrolling.
+// The pages can optionally be cached locally for each query text.
+type InlinePager struct {
+	api    API
+	source InlineResultSource
+	// PageSize is the number of results sent in each answer, MaxInlineQueryResults by default.
+	PageSize int
+
+	cache map[string]inlinePage
+	ttl   time.Duration
+	mu    sync.Mutex
+}
+
+// NewInlinePager returns a new instance of the InlinePager object.
+func NewInlinePager(api API, source InlineResultSource) *InlinePager {
+	return &InlinePager{api: api, source: source, PageSize: MaxInlineQueryResults}
+}
+
+// SetCache enables caching the pages of results for the given ttl, a ttl of 0 disables the cache.
+// The pages are cached by query text, or by user and query text if the answers are personal.
+func (p *InlinePager) SetCache(ttl time.Duration) {
+	p.mu.Lock()
+	p.ttl = ttl
+	p.cache = nil
+	if ttl > 0 {
+		p.cache = make(map[string]inlinePage)
+	}
+	p.mu.Unlock()
+}
+
+// Answer answers the inline query with the page of results starting from its Offset.
+// The NextOffset field of opts is filled automatically.
+func (p *InlinePager) Answer(q *InlineQuery, opts *InlineQueryOptions) (res APIResponseBase, err error) {
+	var o InlineQueryOptions
+	if opts != nil {
+		o = *opts
+	}
+
+	page, err := p.page(q, o.IsPersonal)
+	if err != nil {
+		return
+	}
+
+	o.NextOffset = page.next
+	return p.api.AnswerInlineQuery(q.ID, page.results, &o)
+}
+
+// Update answers the inline query contained in the update, if any, and returns true if the update has been used.
+func (p *InlinePager) Update(u *Update, opts *InlineQueryOptions) (bool, error) {
+	if u.InlineQuery == nil {
+		return false, nil
+	}
+
+	_, err := p.Answer(u.InlineQuery, opts)
+	return true, err
+}
+
+// page returns the page of results requested by the inline query, from the cache if possible.
+func (p *InlinePager) page(q *InlineQuery, personal bool) (inlinePage, error) {
+	var key = q.Query + "\x00" + q.Offset
+	if personal && q.From != nil {
+		key = strconv.FormatInt(q.From.ID, 10) + "\x00" + key
+	}
+
+	if pg, ok := p.cached(key); ok {
+		return pg, nil
+	}
+
+	size := p.PageSize
+	if size <= 0 || size > MaxInlineQueryResults {
+		size = MaxInlineQueryResults
+	}
+
+	// An empty or invalid offset means the first page.
+	offset, _ := strconv.Atoi(q.Offset)
+	if offset < 0 {
+		offset = 0
+	}
+
+	results, err := p.source(q, offset, size)
+	if err != nil {
+		return inlinePage{}, err
+	}
+
+	var pg inlinePage
+	if len(results) > size {
+		results = results[:size]
+	}
+	if len(results) == size {
+		pg.next = strconv.Itoa(offset + size)
+	}
+
+	if pg.results, err = prepareInlineResults(results); err != nil {
+		return inlinePage{}, err
+	}
+
+	p.store(key, pg)
+	return pg, nil
+}
+
+func (p *InlinePager) cached(key string) (inlinePage, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pg, ok := p.cache[key]
+	if !ok || time.Now().After(pg.expires) {
+		return inlinePage{}, false
+	}
+	return pg, true
+}
+
+func (p *InlinePager) store(key string, pg inlinePage) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.cache == nil {
+		return
+	}
+
+	now := time.Now()
+	for k, v := range p.cache {
+		if now.After(v.expires) {
+			delete(p.cache, k)
+		}
+	}
+
+	pg.expires = now.Add(p.ttl)
+	p.cache[key] = pg
+}
@@ -0,0 +1,64 @@
+package echotron
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func articlesSource(n int, calls *int) InlineResultSource {
+	return func(q *InlineQuery, offset, limit int) (res []InlineQueryResult, err error) {
+		*calls++
+		for i := offset; i < offset+limit && i < n; i++ {
+			res = append(res, InlineQueryResultArticle{
+				Type:                InlineArticle,
+				ID:                  fmt.Sprint(i),
+				Title:               q.Query,
+				InputMessageContent: InputTextMessageContent{MessageText: "a&b"},
+			})
+		}
+		return
+	}
+}
+
+func TestInlinePager(t *testing.T) {
+	var calls int
+	a, reqs := newMockAPI(t, "true")
+	p := NewInlinePager(a, articlesSource(70, &calls))
+	p.SetCache(time.Minute)
+
+	if _, err := p.Answer(&InlineQuery{ID: "1", Query: "q"}, &InlineQueryOptions{CacheTime: 10}); err != nil {
+		t.Fatal(err)
+	}
+
+	r := <-reqs
+	var results []map[string]interface{}
+	if err := json.Unmarshal([]byte(r.query.Get("results")), &results); err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 50 || r.query.Get("next_offset") != "50" || r.query.Get("cache_time") != "10" {
+		t.Fatalf("unexpected request with %d results %+v", len(results), r.query)
+	}
+
+	if _, err := p.Answer(&InlineQuery{ID: "2", Query: "q", Offset: "50"}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	r = <-reqs
+	if err := json.Unmarshal([]byte(r.query.Get("results")), &results); err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 20 || r.query.Get("next_offset") != "" {
+		t.Fatalf("unexpected request with %d results %+v", len(results), r.query)
+	}
+
+	// The first page is served from the cache.
+	if _, err := p.Answer(&InlineQuery{ID: "3", Query: "q"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	<-reqs
+	if calls != 2 {
+		t.Fatalf("expected 2 calls to the source, got %d", calls)
+	}
+}
@@ -0,0 +1,210 @@
+/*
+ * Echotron
+ * Copyright (C) 2018-2022 The Echotron Devs
+ *
+ * Echotron is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Echotron is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package echotron
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const (
+	// MaxInlineQueryResults is the maximum number of results allowed in a single answer to an inline query.
+	MaxInlineQueryResults = 50
+	// MaxInlineResultIDLength is the maximum length in bytes of the ID of an InlineQueryResult.
+	MaxInlineResultIDLength = 64
+)
+
+var (
+	// ErrTooManyResults is returned when answering an inline query with more than MaxInlineQueryResults results.
+	ErrTooManyResults = errors.New("too many inline query results")
+	// ErrDuplicateResultID is returned when two results of the same answer share the same ID.
+	ErrDuplicateResultID = errors.New("duplicate inline query result ID")
+	// ErrInvalidInlineResult is returned when an inline query result lacks a required field or has an invalid one.
+	ErrInvalidInlineResult = errors.New("invalid inline query result")
+)
+
+// inlineResultTypes maps each InlineQueryResult* type to the value of its Type field.
+var inlineResultTypes = map[reflect.Type]InlineQueryType{
+	reflect.TypeOf(InlineQueryResultArticle{}):        InlineArticle,
+	reflect.TypeOf(InlineQueryResultPhoto{}):          InlinePhoto,
+	reflect.TypeOf(InlineQueryResultGif{}):            InlineGIF,
+	reflect.TypeOf(InlineQueryResultMpeg4Gif{}):       InlineMPEG4GIF,
+	reflect.TypeOf(InlineQueryResultVideo{}):          InlineVideo,
+	reflect.TypeOf(InlineQueryResultAudio{}):          InlineAudio,
+	reflect.TypeOf(InlineQueryResultVoice{}):          InlineVoice,
+	reflect.TypeOf(InlineQueryResultDocument{}):       InlineDocument,
+	reflect.TypeOf(InlineQueryResultLocation{}):       InlineLocation,
+	reflect.TypeOf(InlineQueryResultVenue{}):          InlineVenue,
+	reflect.TypeOf(InlineQueryResultContact{}):        InlineContact,
+	reflect.TypeOf(InlineQueryResultGame{}):           InlineGame,
+	reflect.TypeOf(InlineQueryResultCachedPhoto{}):    InlinePhoto,
+	reflect.TypeOf(InlineQueryResultCachedGif{}):      InlineGIF,
+	reflect.TypeOf(InlineQueryResultCachedMpeg4Gif{}): InlineMPEG4GIF,
+	reflect.TypeOf(InlineQueryResultCachedSticker{}):  InlineSticker,
+	reflect.TypeOf(InlineQueryResultCachedDocument{}): InlineDocument,
+	reflect.TypeOf(InlineQueryResultCachedVideo{}):    InlineVideo,
+	reflect.TypeOf(InlineQueryResultCachedVoice{}):    InlineVoice,
+	reflect.TypeOf(InlineQueryResultCachedAudio{}):    InlineAudio,
+}
+
+// ValidateInlineResults checks that the results can be sent in a single answer to an inline query:
+// there must be at most MaxInlineQueryResults of them, each one with a Type matching its Go type, a unique ID of at most
+// MaxInlineResultIDLength bytes, the file ID of the cached results and a valid InputMessageContent, if any.
+func ValidateInlineResults(results []InlineQueryResult) error {
+	if len(results) > MaxInlineQueryResults {
+		return fmt.Errorf("%w: %d results, at most %d allowed", ErrTooManyResults, len(results), MaxInlineQueryResults)
+	}
+
+	var ids = make(map[string]bool, len(results))
+	for _, r := range results {
+		if err := validateInlineResult(r); err != nil {
+			return err
+		}
+
+		id := inlineResultString(r, "ID")
+		if ids[id] {
+			return fmt.Errorf("%w: %q", ErrDuplicateResultID, id)
+		}
+		ids[id] = true
+	}
+
+	return nil
+}
+
+// prepareInlineResults returns a copy of the results with the missing Type fields filled
+// according to their concrete type, after checking them with ValidateInlineResults,
+// which rejects the Type fields that don't match the concrete type.
+func prepareInlineResults(results []InlineQueryResult) ([]InlineQueryResult, error) {
+	var ret = make([]InlineQueryResult, len(results))
+
+	for i, r := range results {
+		ret[i] = r
+
+		v := reflect.Indirect(reflect.ValueOf(r))
+		if !v.IsValid() {
+			continue
+		}
+
+		typ, ok := inlineResultTypes[v.Type()]
+		if !ok || v.FieldByName("Type").String() != "" {
+			continue
+		}
+
+		cp := reflect.New(v.Type())
+		cp.Elem().Set(v)
+		cp.Elem().FieldByName("Type").SetString(string(typ))
+		ret[i] = cp.Elem().Interface().(InlineQueryResult)
+	}
+
+	return ret, ValidateInlineResults(ret)
+}
+
+func validateInlineResult(r InlineQueryResult) error {
+	v := reflect.Indirect(reflect.ValueOf(r))
+	if v.Kind() != reflect.Struct {
+		return fmt.Errorf("%w: nil result", ErrInvalidInlineResult)
+	}
+
+	id := inlineResultString(r, "ID")
+	if id == "" {
+		return fmt.Errorf("%w: empty ID", ErrInvalidInlineResult)
+	}
+	if len(id) > MaxInlineResultIDLength {
+		return fmt.Errorf("%w: ID %q has %d bytes, at most %d allowed", ErrInvalidInlineResult, id, len(id), MaxInlineResultIDLength)
+	}
+
+	typ := inlineResultString(r, "Type")
+	if typ == "" {
+		return fmt.Errorf("%w: result %q has no type", ErrInvalidInlineResult, id)
+	}
+	if want, ok := inlineResultTypes[v.Type()]; ok && typ != string(want) {
+		return fmt.Errorf("%w: result %q of type %s has Type %q, expected %q", ErrInvalidInlineResult, id, v.Type().Name(), typ, want)
+	}
+
+	if strings.HasPrefix(v.Type().Name(), "InlineQueryResultCached") {
+		for i := 0; i < v.NumField(); i++ {
+			if strings.HasSuffix(v.Type().Field(i).Name, "FileID") && v.Field(i).String() == "" {
+				return fmt.Errorf("%w: result %q has an empty %s", ErrInvalidInlineResult, id, v.Type().Field(i).Name)
+			}
+		}
+	}
+
+	if c := inlineResultField(r, "InputMessageContent"); c.IsValid() && !c.IsNil() {
+		if err := validateInputMessageContent(c.Interface().(InputMessageContent)); err != nil {
+			return fmt.Errorf("%w: result %q: %v", ErrInvalidInlineResult, id, err)
+		}
+	} else if _, ok := v.Interface().(InlineQueryResultArticle); ok {
+		return fmt.Errorf("%w: article %q has no InputMessageContent", ErrInvalidInlineResult, id)
+	}
+
+	return nil
+}
+
+// validateInputMessageContent checks that the required fields of the Input*MessageContent are set.
+func validateInputMessageContent(c InputMessageContent) error {
+	v := reflect.Indirect(reflect.ValueOf(c))
+	if !v.IsValid() {
+		return errors.New("nil content")
+	}
+
+	switch c := v.Interface().(type) {
+	case InputTextMessageContent:
+		if c.MessageText == "" {
+			return errors.New("empty message text")
+		}
+	case InputLocationMessageContent:
+		if c.Latitude < -90 || c.Latitude > 90 || c.Longitude < -180 || c.Longitude > 180 {
+			return fmt.Errorf("invalid coordinates %f, %f", c.Latitude, c.Longitude)
+		}
+	case InputVenueMessageContent:
+		if c.Title == "" || c.Address == "" {
+			return errors.New("venue without title or address")
+		}
+	case InputContactMessageContent:
+		if c.PhoneNumber == "" || c.FirstName == "" {
+			return errors.New("contact without phone number or first name")
+		}
+	case InputInvoiceMessageContent:
+		if c.Title == "" || c.Description == "" || c.Payload == "" || c.Currency == "" || len(c.Prices) == 0 {
+			return errors.New("invoice without title, description, payload, currency or prices")
+		}
+	}
+	return nil
+}
+
+// inlineResultField returns the field with the given name of the InlineQueryResult* value,
+// or the zero Value if it doesn't exist.
+func inlineResultField(r InlineQueryResult, name string) reflect.Value {
+	v := reflect.Indirect(reflect.ValueOf(r))
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+	return v.FieldByName(name)
+}
+
+// inlineResultString returns the value of the string field with the given name of the InlineQueryResult* value,
+// or an empty string if it doesn't exist.
+func inlineResultString(r InlineQueryResult, name string) string {
+	if f := inlineResultField(r, name); f.Kind() == reflect.String {
+		return f.String()
+	}
+	return ""
+}
@@ -0,0 +1,88 @@
+package echotron
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestValidateInlineResults(t *testing.T) {
+	text := InputTextMessageContent{MessageText: "text"}
+
+	for _, c := range []struct {
+		results []InlineQueryResult
+		err     error
+	}{
+		{[]InlineQueryResult{InlineQueryResultArticle{Type: InlineArticle, ID: "1", InputMessageContent: text}}, nil},
+		{[]InlineQueryResult{InlineQueryResultArticle{ID: "1", InputMessageContent: text}}, ErrInvalidInlineResult},
+		{[]InlineQueryResult{InlineQueryResultArticle{Type: InlineArticle, InputMessageContent: text}}, ErrInvalidInlineResult},
+		{[]InlineQueryResult{InlineQueryResultArticle{Type: InlineArticle, ID: strings.Repeat("x", 65), InputMessageContent: text}}, ErrInvalidInlineResult},
+		{[]InlineQueryResult{InlineQueryResultArticle{Type: InlineArticle, ID: "1"}}, ErrInvalidInlineResult},
+		{[]InlineQueryResult{InlineQueryResultArticle{Type: InlineArticle, ID: "1", InputMessageContent: InputTextMessageContent{}}}, ErrInvalidInlineResult},
+		{[]InlineQueryResult{InlineQueryResultArticle{Type: InlineArticle, ID: "1", InputMessageContent: &InputContactMessageContent{PhoneNumber: "1"}}}, ErrInvalidInlineResult},
+		{[]InlineQueryResult{InlineQueryResultCachedPhoto{Type: InlinePhoto, ID: "1"}}, ErrInvalidInlineResult},
+		{[]InlineQueryResult{&InlineQueryResultCachedSticker{Type: InlineSticker, ID: "1", StickerFileID: "file"}}, nil},
+		{[]InlineQueryResult{
+			InlineQueryResultCachedSticker{Type: InlineSticker, ID: "1", StickerFileID: "file"},
+			&InlineQueryResultPhoto{Type: InlinePhoto, ID: "1"},
+		}, ErrDuplicateResultID},
+	} {
+		if err := ValidateInlineResults(c.results); !errors.Is(err, c.err) {
+			t.Errorf("expected %v, got %v for %+v", c.err, err, c.results)
+		}
+	}
+
+	var results []InlineQueryResult
+	for i := 0; i < 51; i++ {
+		results = append(results, InlineQueryResultPhoto{Type: InlinePhoto, ID: fmt.Sprint(i)})
+	}
+	if err := ValidateInlineResults(results); !errors.Is(err, ErrTooManyResults) {
+		t.Fatalf("expected ErrTooManyResults, got %v", err)
+	}
+}
+
+func TestPrepareInlineResults(t *testing.T) {
+	original := []InlineQueryResult{
+		InlineQueryResultArticle{ID: "1", InputMessageContent: InputTextMessageContent{MessageText: "text"}},
+		&InlineQueryResultCachedMpeg4Gif{ID: "2", Mpeg4FileID: "file"},
+		InlineQueryResultVoice{Type: InlineVoice, ID: "3"},
+	}
+
+	results, err := prepareInlineResults(original)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jsn, _ := json.Marshal(results)
+	var decoded []struct{ Type string }
+	json.Unmarshal(jsn, &decoded)
+
+	if decoded[0].Type != "article" || decoded[1].Type != "mpeg4_gif" || decoded[2].Type != "voice" {
+		t.Fatalf("unexpected types %+v", decoded)
+	}
+
+	if original[0].(InlineQueryResultArticle).Type != "" {
+		t.Fatal("the original results have been modified")
+	}
+
+	mismatched := []InlineQueryResult{InlineQueryResultVoice{Type: InlinePhoto, ID: "1"}}
+	if _, err := prepareInlineResults(mismatched); !errors.Is(err, ErrInvalidInlineResult) {
+		t.Fatalf("expected ErrInvalidInlineResult, got %v", err)
+	}
+}
+
+func TestAnswerInlineQueryValidation(t *testing.T) {
+	a, reqs := newMockAPI(t, "true")
+
+	if _, err := a.AnswerInlineQuery("1", []InlineQueryResult{InlineQueryResultCachedAudio{ID: "1"}}, nil); !errors.Is(err, ErrInvalidInlineResult) {
+		t.Fatalf("expected ErrInvalidInlineResult, got %v", err)
+	}
+
+	select {
+	case r := <-reqs:
+		t.Fatalf("unexpected request %+v", r)
+	default:
+	}
+}
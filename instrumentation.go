@@ -0,0 +1,152 @@
+/*
+ * Echotron
+ * Copyright (C) 2018-2022 The Echotron Devs
+ *
+ * Echotron is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Echotron is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package echotron
+
+import "time"
+
+// RequestResult contains the outcome of a request to the Telegram API.
+type RequestResult struct {
+	Method      string
+	Duration    time.Duration
+	Status      int
+	ErrorCode   int
+	Description string
+	Err         error
+}
+
+// Instrumentation is the interface that allows to observe the requests sent by the API
+// and the updates handled by the Dispatcher, e.g. to collect metrics or traces.
+type Instrumentation interface {
+	// StartRequest is called before each request to the Telegram API.
+	// The returned function is called with the outcome of the request once it has completed.
+	StartRequest(method string) func(RequestResult)
+	// StartUpdate is called before each call to the Update method of a Bot.
+	// The returned function is called once the Update method has returned.
+	StartUpdate(chatID int64, update *Update) func()
+}
+
+// QueueInstrumentation is an optional interface an Instrumentation can implement
+// to observe the updates received by the Dispatcher and not yet dispatched to the bots.
+type QueueInstrumentation interface {
+	// ObservePendingUpdates is called by Dispatcher.SetInstrumentation with a function
+	// returning the current number of pending updates, which is safe to call at any time.
+	ObservePendingUpdates(fn func() int)
+}
+
+// multiInstrumentation forwards every hook to a list of Instrumentation.
+type multiInstrumentation []Instrumentation
+
+// MultiInstrumentation returns an Instrumentation that calls the hooks of each of the given ones, in order.
+func MultiInstrumentation(instr ...Instrumentation) Instrumentation {
+	return multiInstrumentation(instr)
+}
+
+func (m multiInstrumentation) StartRequest(method string) func(RequestResult) {
+	var done = make([]func(RequestResult), len(m))
+
+	for i, instr := range m {
+		done[i] = instr.StartRequest(method)
+	}
+
+	return func(res RequestResult) {
+		for _, fn := range done {
+			fn(res)
+		}
+	}
+}
+
+func (m multiInstrumentation) StartUpdate(chatID int64, update *Update) func() {
+	var done = make([]func(), len(m))
+
+	for i, instr := range m {
+		done[i] = instr.StartUpdate(chatID, update)
+	}
+
+	return func() {
+		for _, fn := range done {
+			fn()
+		}
+	}
+}
+
+func (m multiInstrumentation) ObservePendingUpdates(fn func() int) {
+	for _, instr := range m {
+		if q, ok := instr.(QueueInstrumentation); ok {
+			q.ObservePendingUpdates(fn)
+		}
+	}
+}
+
+// Span represents a single traced operation, such as an OpenTelemetry span.
+type Span interface {
+	// SetAttribute sets an attribute on the span.
+	SetAttribute(key string, value interface{})
+	// End completes the span, err is nil if the operation succeeded.
+	End(err error)
+}
+
+// Tracer creates the spans used by the Instrumentation returned by NewTracingInstrumentation.
+// It's meant to be a thin adapter on top of a tracing library such as OpenTelemetry.
+type Tracer interface {
+	StartSpan(name string, attrs map[string]interface{}) Span
+}
+
+// tracingInstrumentation is an Instrumentation that creates a span for each request and update.
+type tracingInstrumentation struct {
+	tracer Tracer
+}
+
+// NewTracingInstrumentation returns an Instrumentation that creates a "telegram.<method>" span
+// for each request to the Telegram API and an "echotron.update" span for each call to the Update method of a Bot.
+// Span attributes follow the OpenTelemetry naming conventions where applicable.
+func NewTracingInstrumentation(t Tracer) Instrumentation {
+	return tracingInstrumentation{t}
+}
+
+func (t tracingInstrumentation) StartRequest(method string) func(RequestResult) {
+	span := t.tracer.StartSpan("telegram."+method, map[string]interface{}{
+		"telegram.method": method,
+	})
+
+	return func(res RequestResult) {
+		if res.Status != 0 {
+			span.SetAttribute("http.status_code", res.Status)
+		}
+
+		err := res.Err
+		if res.ErrorCode != 0 {
+			span.SetAttribute("telegram.error_code", res.ErrorCode)
+			if err == nil {
+				err = &APIError{res.ErrorCode, res.Description}
+			}
+		}
+		span.End(err)
+	}
+}
+
+func (t tracingInstrumentation) StartUpdate(chatID int64, update *Update) func() {
+	span := t.tracer.StartSpan("echotron.update", map[string]interface{}{
+		"telegram.chat_id":   chatID,
+		"telegram.update_id": update.ID,
+	})
+
+	return func() {
+		span.End(nil)
+	}
+}
@@ -0,0 +1,166 @@
+package echotron
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type memSpan struct {
+	name  string
+	attrs map[string]interface{}
+	err   error
+	ended bool
+}
+
+func (s *memSpan) SetAttribute(key string, value interface{}) {
+	s.attrs[key] = value
+}
+
+func (s *memSpan) End(err error) {
+	s.err = err
+	s.ended = true
+}
+
+type memTracer struct {
+	spans []*memSpan
+	mu    sync.Mutex
+}
+
+func (m *memTracer) StartSpan(name string, attrs map[string]interface{}) Span {
+	s := &memSpan{name: name, attrs: attrs}
+	m.mu.Lock()
+	m.spans = append(m.spans, s)
+	m.mu.Unlock()
+	return s
+}
+
+func TestInstrumentationRequest(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "getMe") {
+			w.Write([]byte(`{"ok":true,"result":{"id":1,"is_bot":true,"first_name":"test"}}`))
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"ok":false,"error_code":403,"description":"Forbidden: bot was blocked by the user"}`))
+	}))
+	defer srv.Close()
+
+	var (
+		tracer  memTracer
+		metrics = NewMetrics()
+		a       = API{token: "token", base: srv.URL + "/bottoken/"}
+	)
+
+	a.SetInstrumentation(MultiInstrumentation(metrics, NewTracingInstrumentation(&tracer)))
+
+	if _, err := a.GetMe(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.SendMessage("test", 1, nil); err == nil {
+		t.Fatal("expected an API error")
+	}
+
+	if n := metrics.Requests("getMe"); n != 1 {
+		t.Fatalf("expected 1 getMe request, got %d", n)
+	}
+
+	rec := httptest.NewRecorder()
+	metrics.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body := rec.Body.String()
+
+	for _, line := range []string{
+		`echotron_api_requests_total{method="getMe",code="ok"} 1`,
+		`echotron_api_requests_total{method="sendMessage",code="403"} 1`,
+		`echotron_api_request_duration_seconds_count{method="sendMessage"} 1`,
+	} {
+		if !strings.Contains(body, line) {
+			t.Fatalf("missing %q in:\n%s", line, body)
+		}
+	}
+
+	if len(tracer.spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(tracer.spans))
+	}
+
+	span := tracer.spans[1]
+	if span.name != "telegram.sendMessage" || !span.ended {
+		t.Fatalf("unexpected span %+v", span)
+	}
+	if span.attrs["telegram.error_code"] != 403 || span.err == nil {
+		t.Fatalf("error not recorded on span %+v", span)
+	}
+}
+
+type blockingBot struct {
+	release chan struct{}
+	done    chan struct{}
+}
+
+func (b blockingBot) Update(_ *Update) {
+	<-b.release
+	b.done <- struct{}{}
+}
+
+func TestInstrumentationUpdate(t *testing.T) {
+	var (
+		metrics = NewMetrics()
+		bot     = blockingBot{make(chan struct{}), make(chan struct{})}
+		d       = NewDispatcher("token", func(_ int64) Bot { return bot })
+	)
+
+	d.SetInstrumentation(metrics)
+	d.updates <- &Update{Message: &Message{Chat: &Chat{ID: 1}}}
+
+	deadline := time.Now().Add(time.Second)
+	for metrics.InFlightUpdates() != 1 {
+		if time.Now().After(deadline) {
+			t.Fatal("update not reported as in flight")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	close(bot.release)
+	<-bot.done
+
+	deadline = time.Now().Add(time.Second)
+	for metrics.InFlightUpdates() != 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("update still reported as in flight")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestInstrumentationPendingUpdates(t *testing.T) {
+	var (
+		metrics = NewMetrics()
+		updates = make(chan *Update, 1)
+		d       = NewDispatcher("token", func(_ int64) Bot { return replayBot{updates} })
+	)
+
+	d.SetInstrumentation(MultiInstrumentation(metrics, NewTracingInstrumentation(&memTracer{})))
+	d.enqueue(&Update{Message: &Message{Chat: &Chat{ID: 1}}})
+	<-updates
+
+	if n := metrics.PendingUpdates(); n != 0 {
+		t.Fatalf("expected no pending updates, got %d", n)
+	}
+
+	// The gauge is read from the Dispatcher when the metrics are collected.
+	atomic.AddInt32(&d.pending, 2)
+	if n := metrics.PendingUpdates(); n != 2 {
+		t.Fatalf("expected 2 pending updates, got %d", n)
+	}
+	atomic.AddInt32(&d.pending, -2)
+
+	w := httptest.NewRecorder()
+	metrics.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	if !strings.Contains(w.Body.String(), "echotron_updates_pending 0\n") {
+		t.Fatalf("pending updates gauge missing:\n%s", w.Body.String())
+	}
+}
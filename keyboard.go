@@ -0,0 +1,380 @@
+/*
+ * Echotron
+ * Copyright (C) 2018-2022 The Echotron Devs
+ *
+ * Echotron is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Echotron is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package echotron
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+const (
+	// MaxCallbackDataLength is the maximum length in bytes of the CallbackData of an InlineKeyboardButton.
+	MaxCallbackDataLength = 64
+	// MaxInlineKeyboardButtons is the maximum number of buttons of an InlineKeyboardMarkup.
+	MaxInlineKeyboardButtons = 100
+)
+
+var (
+	// ErrCallbackDataTooLong is returned when the CallbackData of a button exceeds MaxCallbackDataLength bytes.
+	ErrCallbackDataTooLong = errors.New("callback data too long")
+	// ErrTooManyButtons is returned when a keyboard contains more than MaxInlineKeyboardButtons buttons.
+	ErrTooManyButtons = errors.New("too many buttons in keyboard")
+	// ErrInvalidButton is returned when a button has no text or doesn't specify exactly one action.
+	ErrInvalidButton = errors.New("invalid keyboard button")
+)
+
+// CallbackButton returns an InlineKeyboardButton which sends a callback query with the given data when pressed.
+func CallbackButton(text, data string) InlineKeyboardButton {
+	return InlineKeyboardButton{Text: text, CallbackData: data}
+}
+
+// URLButton returns an InlineKeyboardButton which opens the given URL when pressed.
+func URLButton(text, url string) InlineKeyboardButton {
+	return InlineKeyboardButton{Text: text, URL: url}
+}
+
+// LoginButton returns an InlineKeyboardButton used to automatically authorize the user on a website.
+func LoginButton(text string, login LoginURL) InlineKeyboardButton {
+	return InlineKeyboardButton{Text: text, LoginURL: &login}
+}
+
+// SwitchInlineButton returns an InlineKeyboardButton which prompts the user to select one of their chats
+// and inserts the bot's username and the given query in the input field.
+// Since empty fields are omitted from the request, query must not be empty.
+func SwitchInlineButton(text, query string) InlineKeyboardButton {
+	return InlineKeyboardButton{Text: text, SwitchInlineQuery: query}
+}
+
+// SwitchInlineCurrentChatButton returns an InlineKeyboardButton which inserts the bot's username
+// and the given query in the input field of the current chat.
+// Since empty fields are omitted from the request, query must not be empty.
+func SwitchInlineCurrentChatButton(text, query string) InlineKeyboardButton {
+	return InlineKeyboardButton{Text: text, SwitchInlineQueryCurrentChat: query}
+}
+
+// PayButton returns an InlineKeyboardButton used to pay an invoice.
+// It must always be the first button of the first row of the keyboard.
+func PayButton(text string) InlineKeyboardButton {
+	return InlineKeyboardButton{Text: text, Pay: true}
+}
+
+// GameButton returns an InlineKeyboardButton used to launch a game.
+// It must always be the first button of the first row of the keyboard.
+func GameButton(text string) InlineKeyboardButton {
+	return InlineKeyboardButton{Text: text, CallbackGame: &CallbackGame{}}
+}
+
+// WebAppButton returns an InlineKeyboardButton which opens the Web App at the given URL when pressed.
+// Available in private chats only.
+func WebAppButton(text, url string) InlineKeyboardButton {
+	return InlineKeyboardButton{Text: text, WebApp: &WebAppInfo{URL: url}}
+}
+
+// TextButton returns a KeyboardButton which sends its text as a message when pressed.
+func TextButton(text string) KeyboardButton {
+	return KeyboardButton{Text: text}
+}
+
+// ContactButton returns a KeyboardButton which sends the user's phone number when pressed.
+// Available in private chats only.
+func ContactButton(text string) KeyboardButton {
+	return KeyboardButton{Text: text, RequestContact: true}
+}
+
+// LocationButton returns a KeyboardButton which sends the user's current location when pressed.
+// Available in private chats only.
+func LocationButton(text string) KeyboardButton {
+	return KeyboardButton{Text: text, RequestLocation: true}
+}
+
+// PollButton returns a KeyboardButton which asks the user to create a poll of the given type
+// and send it to the bot when pressed. An empty type allows the user to create a poll of any type.
+// Available in private chats only.
+func PollButton(text string, typ PollType) KeyboardButton {
+	return KeyboardButton{Text: text, RequestPoll: &KeyboardButtonPollType{Type: typ}}
+}
+
+// RequestUsersButton returns a KeyboardButton which asks the user to select the users matching the criteria
+// of req when pressed, whose identifiers are then shared with the bot in a UsersShared service message.
+// Available in private chats only.
+func RequestUsersButton(text string, req KeyboardButtonRequestUsers) KeyboardButton {
+	return KeyboardButton{Text: text, RequestUsers: &req}
+}
+
+// RequestChatButton returns a KeyboardButton which asks the user to select a chat matching the criteria
+// of req when pressed, whose identifier is then shared with the bot in a ChatShared service message.
+// Available in private chats only.
+func RequestChatButton(text string, req KeyboardButtonRequestChat) KeyboardButton {
+	return KeyboardButton{Text: text, RequestChat: &req}
+}
+
+// KeyboardWebAppButton returns a KeyboardButton which opens the Web App at the given URL when pressed.
+// The Web App will be able to send a WebAppData service message.
+// Available in private chats only.
+func KeyboardWebAppButton(text, url string) KeyboardButton {
+	return KeyboardButton{Text: text, WebApp: &WebAppInfo{URL: url}}
+}
+
+// columnsLayout returns the size of the rows needed to lay out count buttons in n columns.
+func columnsLayout(n, count int) (rows []int) {
+	if n <= 0 {
+		n = count
+	}
+
+	for count > 0 {
+		if count < n {
+			n = count
+		}
+		rows = append(rows, n)
+		count -= n
+	}
+
+	return
+}
+
+// wrapLayout returns the size of the rows needed to lay out buttons with the given texts
+// so that the total number of characters of each row doesn't exceed width.
+// Each row contains at least one button.
+func wrapLayout(width int, texts []string) (rows []int) {
+	var n, w int
+
+	for _, t := range texts {
+		l := utf8.RuneCountInString(t)
+		if n > 0 && w+l > width {
+			rows = append(rows, n)
+			n, w = 0, 0
+		}
+		n++
+		w += l
+	}
+
+	if n > 0 {
+		rows = append(rows, n)
+	}
+
+	return
+}
+
+// InlineKeyboardBuilder is used to build an InlineKeyboardMarkup row by row.
+type InlineKeyboardBuilder struct {
+	rows [][]InlineKeyboardButton
+}
+
+// NewInlineKeyboard returns a new instance of the InlineKeyboardBuilder object.
+func NewInlineKeyboard() *InlineKeyboardBuilder {
+	return &InlineKeyboardBuilder{}
+}
+
+// Row appends a row made of the given buttons.
+func (b *InlineKeyboardBuilder) Row(buttons ...InlineKeyboardButton) *InlineKeyboardBuilder {
+	if len(buttons) > 0 {
+		b.rows = append(b.rows, buttons)
+	}
+	return b
+}
+
+// Columns appends the given buttons laid out in rows of n buttons each.
+func (b *InlineKeyboardBuilder) Columns(n int, buttons ...InlineKeyboardButton) *InlineKeyboardBuilder {
+	for _, l := range columnsLayout(n, len(buttons)) {
+		b.Row(buttons[:l]...)
+		buttons = buttons[l:]
+	}
+	return b
+}
+
+// Wrap appends the given buttons laid out in rows whose texts have at most width characters in total.
+func (b *InlineKeyboardBuilder) Wrap(width int, buttons ...InlineKeyboardButton) *InlineKeyboardBuilder {
+	var texts = make([]string, len(buttons))
+	for i, btn := range buttons {
+		texts[i] = btn.Text
+	}
+
+	for _, l := range wrapLayout(width, texts) {
+		b.Row(buttons[:l]...)
+		buttons = buttons[l:]
+	}
+	return b
+}
+
+// Build returns the InlineKeyboardMarkup built so far, or an error if it doesn't satisfy the Telegram constraints.
+func (b *InlineKeyboardBuilder) Build() (InlineKeyboardMarkup, error) {
+	kbd := InlineKeyboardMarkup{InlineKeyboard: b.rows}
+	return kbd, kbd.Validate()
+}
+
+// Validate checks that the keyboard satisfies the constraints imposed by Telegram:
+// it must contain at most MaxInlineKeyboardButtons buttons, each one with a text, exactly one action
+// and a CallbackData of at most MaxCallbackDataLength bytes.
+func (i InlineKeyboardMarkup) Validate() error {
+	var count int
+
+	for _, row := range i.InlineKeyboard {
+		for _, btn := range row {
+			if err := btn.validate(); err != nil {
+				return err
+			}
+			count++
+		}
+	}
+
+	if count > MaxInlineKeyboardButtons {
+		return fmt.Errorf("%w: %d buttons, at most %d allowed", ErrTooManyButtons, count, MaxInlineKeyboardButtons)
+	}
+	return nil
+}
+
+func (i InlineKeyboardButton) validate() error {
+	if i.Text == "" {
+		return fmt.Errorf("%w: empty text", ErrInvalidButton)
+	}
+
+	if l := len(i.CallbackData); l > MaxCallbackDataLength {
+		return fmt.Errorf("%w: button %q has %d bytes, at most %d allowed", ErrCallbackDataTooLong, i.Text, l, MaxCallbackDataLength)
+	}
+
+	var actions int
+	for _, set := range []bool{
+		i.URL != "",
+		i.LoginURL != nil,
+		i.CallbackData != "",
+		i.SwitchInlineQuery != "",
+		i.SwitchInlineQueryCurrentChat != "",
+		i.CallbackGame != nil,
+		i.Pay,
+		i.WebApp != nil,
+	} {
+		if set {
+			actions++
+		}
+	}
+
+	if actions != 1 {
+		return fmt.Errorf("%w: button %q must have exactly one action, %d found", ErrInvalidButton, i.Text, actions)
+	}
+	return nil
+}
+
+// ReplyKeyboardBuilder is used to build a ReplyKeyboardMarkup row by row.
+type ReplyKeyboardBuilder struct {
+	kbd ReplyKeyboardMarkup
+}
+
+// NewReplyKeyboard returns a new instance of the ReplyKeyboardBuilder object.
+func NewReplyKeyboard() *ReplyKeyboardBuilder {
+	return &ReplyKeyboardBuilder{}
+}
+
+// Row appends a row made of the given buttons.
+func (b *ReplyKeyboardBuilder) Row(buttons ...KeyboardButton) *ReplyKeyboardBuilder {
+	if len(buttons) > 0 {
+		b.kbd.Keyboard = append(b.kbd.Keyboard, buttons)
+	}
+	return b
+}
+
+// Columns appends the given buttons laid out in rows of n buttons each.
+func (b *ReplyKeyboardBuilder) Columns(n int, buttons ...KeyboardButton) *ReplyKeyboardBuilder {
+	for _, l := range columnsLayout(n, len(buttons)) {
+		b.Row(buttons[:l]...)
+		buttons = buttons[l:]
+	}
+	return b
+}
+
+// Wrap appends the given buttons laid out in rows whose texts have at most width characters in total.
+func (b *ReplyKeyboardBuilder) Wrap(width int, buttons ...KeyboardButton) *ReplyKeyboardBuilder {
+	var texts = make([]string, len(buttons))
+	for i, btn := range buttons {
+		texts[i] = btn.Text
+	}
+
+	for _, l := range wrapLayout(width, texts) {
+		b.Row(buttons[:l]...)
+		buttons = buttons[l:]
+	}
+	return b
+}
+
+// Resize requests clients to resize the keyboard vertically for optimal fit.
+func (b *ReplyKeyboardBuilder) Resize() *ReplyKeyboardBuilder {
+	b.kbd.ResizeKeyboard = true
+	return b
+}
+
+// OneTime requests clients to hide the keyboard as soon as it's been used.
+func (b *ReplyKeyboardBuilder) OneTime() *ReplyKeyboardBuilder {
+	b.kbd.OneTimeKeyboard = true
+	return b
+}
+
+// Placeholder sets the placeholder shown in the input field when the keyboard is active.
+func (b *ReplyKeyboardBuilder) Placeholder(text string) *ReplyKeyboardBuilder {
+	b.kbd.InputFieldPlaceholder = text
+	return b
+}
+
+// Selective shows the keyboard only to the users mentioned in the message
+// or to the sender of the message the bot is replying to.
+func (b *ReplyKeyboardBuilder) Selective() *ReplyKeyboardBuilder {
+	b.kbd.Selective = true
+	return b
+}
+
+// Build returns the ReplyKeyboardMarkup built so far, or an error if it doesn't satisfy the Telegram constraints.
+func (b *ReplyKeyboardBuilder) Build() (ReplyKeyboardMarkup, error) {
+	return b.kbd, b.kbd.Validate()
+}
+
+// Validate checks that each button of the keyboard has a text and at most one request field set.
+func (r ReplyKeyboardMarkup) Validate() error {
+	for _, row := range r.Keyboard {
+		for _, btn := range row {
+			if err := btn.validate(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (k KeyboardButton) validate() error {
+	if k.Text == "" {
+		return fmt.Errorf("%w: empty text", ErrInvalidButton)
+	}
+
+	var requests int
+	for _, set := range []bool{
+		k.RequestContact,
+		k.RequestLocation,
+		k.RequestUsers != nil,
+		k.RequestChat != nil,
+		k.RequestPoll != nil,
+		k.WebApp != nil,
+	} {
+		if set {
+			requests++
+		}
+	}
+
+	if requests > 1 {
+		return fmt.Errorf("%w: button %q must have at most one request, %d found", ErrInvalidButton, k.Text, requests)
+	}
+	return nil
+}
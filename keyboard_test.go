@@ -0,0 +1,89 @@
+package echotron
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestInlineKeyboardColumns(t *testing.T) {
+	kbd, err := NewInlineKeyboard().
+		Columns(2, CallbackButton("1", "1"), CallbackButton("2", "2"), CallbackButton("3", "3")).
+		Row(URLButton("Docs", "https://example.com")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := [][]InlineKeyboardButton{
+		{{Text: "1", CallbackData: "1"}, {Text: "2", CallbackData: "2"}},
+		{{Text: "3", CallbackData: "3"}},
+		{{Text: "Docs", URL: "https://example.com"}},
+	}
+	if !reflect.DeepEqual(kbd.InlineKeyboard, expected) {
+		t.Fatalf("unexpected keyboard %+v", kbd.InlineKeyboard)
+	}
+}
+
+func TestInlineKeyboardWrap(t *testing.T) {
+	kbd, err := NewInlineKeyboard().
+		Wrap(8, CallbackButton("abc", "a"), CallbackButton("de", "d"), CallbackButton("fghij", "f"), CallbackButton("very long text", "v")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var lens []int
+	for _, row := range kbd.InlineKeyboard {
+		lens = append(lens, len(row))
+	}
+	if !reflect.DeepEqual(lens, []int{2, 1, 1}) {
+		t.Fatalf("unexpected row sizes %v", lens)
+	}
+}
+
+func TestInlineKeyboardValidate(t *testing.T) {
+	_, err := NewInlineKeyboard().Row(CallbackButton("long", strings.Repeat("x", 65))).Build()
+	if !errors.Is(err, ErrCallbackDataTooLong) {
+		t.Fatalf("expected ErrCallbackDataTooLong, got %v", err)
+	}
+
+	var buttons []InlineKeyboardButton
+	for i := 0; i < 101; i++ {
+		buttons = append(buttons, CallbackButton("b", "b"))
+	}
+	if _, err = NewInlineKeyboard().Columns(8, buttons...).Build(); !errors.Is(err, ErrTooManyButtons) {
+		t.Fatalf("expected ErrTooManyButtons, got %v", err)
+	}
+
+	if _, err = NewInlineKeyboard().Row(InlineKeyboardButton{Text: "none"}).Build(); !errors.Is(err, ErrInvalidButton) {
+		t.Fatalf("expected ErrInvalidButton, got %v", err)
+	}
+
+	if _, err = NewInlineKeyboard().Row(PayButton("Pay"), GameButton("Play"), SwitchInlineButton("Share", "q")).Build(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReplyKeyboard(t *testing.T) {
+	kbd, err := NewReplyKeyboard().
+		Columns(2, ContactButton("Contact"), LocationButton("Location"), PollButton("Quiz", Quiz)).
+		Row(TextButton("Cancel")).
+		Resize().
+		OneTime().
+		Placeholder("Choose").
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(kbd.Keyboard) != 3 || !kbd.ResizeKeyboard || !kbd.OneTimeKeyboard || kbd.InputFieldPlaceholder != "Choose" {
+		t.Fatalf("unexpected keyboard %+v", kbd)
+	}
+
+	_, err = NewReplyKeyboard().Row(KeyboardButton{Text: "both", RequestContact: true, RequestLocation: true}).Build()
+	if !errors.Is(err, ErrInvalidButton) {
+		t.Fatalf("expected ErrInvalidButton, got %v", err)
+	}
+}
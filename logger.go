@@ -0,0 +1,97 @@
+/*
+ * Echotron
+ * Copyright (C) 2018-2022 The Echotron Devs
+ *
+ * Echotron is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Echotron is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package echotron
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// Logger is the interface used by the Dispatcher and the API to log their activity.
+// Each method takes a message followed by alternating key/value pairs,
+// the same convention used by log/slog, so a *slog.Logger can be used as it is.
+type Logger interface {
+	Debug(msg string, keyvals ...interface{})
+	Info(msg string, keyvals ...interface{})
+	Error(msg string, keyvals ...interface{})
+}
+
+// nopLogger is a Logger that discards everything.
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...interface{}) {}
+func (nopLogger) Info(string, ...interface{})  {}
+func (nopLogger) Error(string, ...interface{}) {}
+
+// stdLogger is a Logger that writes to a *log.Logger from the standard library.
+type stdLogger struct {
+	l     *log.Logger
+	debug bool
+}
+
+// NewStdLogger returns a Logger that writes to l in the "msg key=value ..." format.
+// If l is nil the standard logger of the log package is used.
+// Debug messages are written only if debug is true.
+func NewStdLogger(l *log.Logger, debug bool) Logger {
+	if l == nil {
+		l = log.Default()
+	}
+	return stdLogger{l, debug}
+}
+
+func (s stdLogger) Debug(msg string, keyvals ...interface{}) {
+	if s.debug {
+		s.print("DEBUG", msg, keyvals)
+	}
+}
+
+func (s stdLogger) Info(msg string, keyvals ...interface{}) {
+	s.print("INFO", msg, keyvals)
+}
+
+func (s stdLogger) Error(msg string, keyvals ...interface{}) {
+	s.print("ERROR", msg, keyvals)
+}
+
+func (s stdLogger) print(level, msg string, keyvals []interface{}) {
+	var b strings.Builder
+
+	b.WriteString(level)
+	b.WriteByte(' ')
+	b.WriteString(msg)
+
+	for i := 0; i < len(keyvals); i += 2 {
+		if i+1 < len(keyvals) {
+			fmt.Fprintf(&b, " %v=%v", keyvals[i], keyvals[i+1])
+		} else {
+			fmt.Fprintf(&b, " %v", keyvals[i])
+		}
+	}
+
+	s.l.Println(b.String())
+}
+
+// redactToken replaces every occurrence of the token in s.
+func redactToken(s, token string) string {
+	if token == "" {
+		return s
+	}
+	return strings.ReplaceAll(s, token, "<redacted>")
+}
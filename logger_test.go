@@ -0,0 +1,106 @@
+package echotron
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type logEntry struct {
+	msg     string
+	keyvals map[string]interface{}
+}
+
+type memLogger struct {
+	entries []logEntry
+	mu      sync.Mutex
+}
+
+func (m *memLogger) add(msg string, keyvals []interface{}) {
+	e := logEntry{msg: msg, keyvals: make(map[string]interface{})}
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		e.keyvals[fmt.Sprint(keyvals[i])] = keyvals[i+1]
+	}
+
+	m.mu.Lock()
+	m.entries = append(m.entries, e)
+	m.mu.Unlock()
+}
+
+func (m *memLogger) Debug(msg string, keyvals ...interface{}) { m.add(msg, keyvals) }
+func (m *memLogger) Info(msg string, keyvals ...interface{})  { m.add(msg, keyvals) }
+func (m *memLogger) Error(msg string, keyvals ...interface{}) { m.add(msg, keyvals) }
+
+func TestAPILogger(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"ok":false,"error_code":400,"description":"Bad Request: chat not found"}`))
+	}))
+	defer srv.Close()
+
+	var l memLogger
+	a := API{token: "secret", base: srv.URL + "/botsecret/"}
+	a.SetLogger(&l)
+
+	if _, err := a.SendMessage("test", 1, nil); err == nil {
+		t.Fatal("expected an API error")
+	}
+
+	if len(l.entries) != 1 {
+		t.Fatalf("expected 1 log entry, got %d", len(l.entries))
+	}
+
+	e := l.entries[0]
+	if e.keyvals["method"] != "sendMessage" {
+		t.Fatalf("unexpected method %v", e.keyvals["method"])
+	}
+	if e.keyvals["status"] != http.StatusBadRequest {
+		t.Fatalf("unexpected status %v", e.keyvals["status"])
+	}
+	if e.keyvals["error_code"] != 400 {
+		t.Fatalf("unexpected error code %v", e.keyvals["error_code"])
+	}
+}
+
+func TestRedactError(t *testing.T) {
+	var l memLogger
+	a := API{token: "secret", base: "http://127.0.0.1:0/botsecret/"}
+	a.SetLogger(&l)
+
+	_, err := a.GetMe()
+	if err == nil {
+		t.Fatal("expected a connection error")
+	}
+
+	var uerr *url.Error
+	if !errors.As(err, &uerr) {
+		t.Fatalf("expected a *url.Error, got %T", err)
+	}
+
+	if strings.Contains(err.Error(), "secret") {
+		t.Fatalf("token not redacted from error: %v", err)
+	}
+
+	if len(l.entries) != 1 || strings.Contains(fmt.Sprint(l.entries[0].keyvals["error"]), "secret") {
+		t.Fatalf("token not redacted from log: %+v", l.entries)
+	}
+}
+
+func TestStdLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewStdLogger(log.New(&buf, "", 0), false)
+
+	l.Debug("hidden")
+	l.Info("setting webhook", "url", "example.com")
+
+	if got := buf.String(); got != "INFO setting webhook url=example.com\n" {
+		t.Fatalf("unexpected output %q", got)
+	}
+}
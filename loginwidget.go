@@ -0,0 +1,112 @@
+/*
+ * Echotron
+ * Copyright (C) 2018-2022 The Echotron Devs
+ *
+ * Echotron is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Echotron is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package echotron
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// loginFields are the fields of the authorization data covered by its hash.
+var loginFields = []string{"id", "first_name", "last_name", "username", "photo_url", "auth_date"}
+
+// loginUserKey is the context key of the User authenticated by LoginVerifier.Middleware.
+type loginUserKey struct{}
+
+// LoginVerifier verifies the authorization data that Telegram sends to a website
+// after a user logs in with the Telegram Login Widget or with a LoginURL button.
+type LoginVerifier struct {
+	secret []byte
+	maxAge time.Duration
+}
+
+// NewLoginVerifier returns a new instance of the LoginVerifier object for the bot with the given token.
+// The data is rejected with ErrAuthExpired if its auth_date is older than maxAge, a maxAge of 0 disables the check.
+func NewLoginVerifier(token string, maxAge time.Duration) *LoginVerifier {
+	secret := sha256.Sum256([]byte(token))
+	return &LoginVerifier{secret: secret[:], maxAge: maxAge}
+}
+
+// Verify checks the hash and the auth_date of the authorization data and returns the authenticated user.
+// Any parameter other than the fields sent by Telegram is ignored, so that the data can share
+// the query string with the parameters of the website.
+func (v *LoginVerifier) Verify(vals url.Values) (*User, error) {
+	if err := checkDataHash(loginData(vals), v.secret); err != nil {
+		return nil, err
+	}
+
+	if _, err := checkAuthDate(vals.Get("auth_date"), v.maxAge); err != nil {
+		return nil, err
+	}
+
+	id, err := strconv.ParseInt(vals.Get("id"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid id: %w", err)
+	}
+
+	return &User{
+		ID:        id,
+		FirstName: vals.Get("first_name"),
+		LastName:  vals.Get("last_name"),
+		Username:  vals.Get("username"),
+	}, nil
+}
+
+// loginData returns the hash and the fields of the authorization data contained in vals.
+func loginData(vals url.Values) url.Values {
+	var ret = url.Values{"hash": vals["hash"]}
+
+	for _, k := range loginFields {
+		if v, ok := vals[k]; ok {
+			ret[k] = v
+		}
+	}
+	return ret
+}
+
+// VerifyRequest verifies the authorization data contained in the query string of the request.
+func (v *LoginVerifier) VerifyRequest(r *http.Request) (*User, error) {
+	return v.Verify(r.URL.Query())
+}
+
+// Middleware returns an http.Handler which verifies the authorization data of each request before calling next,
+// replying with 401 Unauthorized to the requests that fail the verification.
+// The authenticated user can be retrieved in next with LoginUserFromContext.
+func (v *LoginVerifier) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, err := v.VerifyRequest(r)
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), loginUserKey{}, user)))
+	})
+}
+
+// LoginUserFromContext returns the User authenticated by LoginVerifier.Middleware, if any.
+func LoginUserFromContext(ctx context.Context) (*User, bool) {
+	user, ok := ctx.Value(loginUserKey{}).(*User)
+	return user, ok
+}
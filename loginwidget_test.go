@@ -0,0 +1,124 @@
+package echotron
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// signLoginData signs the given values like Telegram does with the data of the Login Widget.
+func signLoginData(vals url.Values, token string) url.Values {
+	var fields []string
+	for k := range vals {
+		fields = append(fields, k+"="+vals.Get(k))
+	}
+	sort.Strings(fields)
+
+	secret := sha256.Sum256([]byte(token))
+	mac := hmac.New(sha256.New, secret[:])
+	mac.Write([]byte(strings.Join(fields, "\n")))
+	vals.Set("hash", hex.EncodeToString(mac.Sum(nil)))
+	return vals
+}
+
+func TestLoginVerifier(t *testing.T) {
+	v := NewLoginVerifier("token", time.Hour)
+	vals := signLoginData(url.Values{
+		"id":         {"123456"},
+		"first_name": {"John"},
+		"last_name":  {"Doe"},
+		"username":   {"johndoe"},
+		"photo_url":  {"https://t.me/i/userpic/320/johndoe.jpg"},
+		"auth_date":  {strconv.FormatInt(time.Now().Unix(), 10)},
+	}, "token")
+
+	user, err := v.Verify(vals)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.ID != 123456 || user.FirstName != "John" || user.LastName != "Doe" || user.Username != "johndoe" {
+		t.Fatalf("unexpected user %+v", user)
+	}
+
+	if _, err = NewLoginVerifier("other", time.Hour).Verify(vals); !errors.Is(err, ErrInvalidHash) {
+		t.Fatalf("expected ErrInvalidHash, got %v", err)
+	}
+
+	vals.Set("first_name", "Jane")
+	if _, err = v.Verify(vals); !errors.Is(err, ErrInvalidHash) {
+		t.Fatalf("expected ErrInvalidHash, got %v", err)
+	}
+}
+
+func TestLoginVerifierExpired(t *testing.T) {
+	vals := signLoginData(url.Values{
+		"id":         {"123456"},
+		"first_name": {"John"},
+		"auth_date":  {strconv.FormatInt(time.Now().Add(-2*time.Hour).Unix(), 10)},
+	}, "token")
+
+	if _, err := NewLoginVerifier("token", time.Hour).Verify(vals); !errors.Is(err, ErrAuthExpired) {
+		t.Fatalf("expected ErrAuthExpired, got %v", err)
+	}
+
+	if _, err := NewLoginVerifier("token", 0).Verify(vals); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoginVerifierMiddleware(t *testing.T) {
+	v := NewLoginVerifier("token", time.Hour)
+	h := v.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, ok := LoginUserFromContext(r.Context())
+		if !ok {
+			t.Error("missing user in context")
+			return
+		}
+		w.Write([]byte(user.FirstName))
+	}))
+
+	vals := signLoginData(url.Values{
+		"id":         {"123456"},
+		"first_name": {"John"},
+		"auth_date":  {strconv.FormatInt(time.Now().Unix(), 10)},
+	}, "token")
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/login?"+vals.Encode(), nil))
+	if rec.Code != http.StatusOK || rec.Body.String() != "John" {
+		t.Fatalf("unexpected response %d %q", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/login?id=123456&hash=abc", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", rec.Code)
+	}
+}
+
+func TestLoginVerifierExtraParams(t *testing.T) {
+	v := NewLoginVerifier("token", time.Hour)
+	vals := signLoginData(url.Values{
+		"id":         {"123456"},
+		"first_name": {"John"},
+		"auth_date":  {strconv.FormatInt(time.Now().Unix(), 10)},
+	}, "token")
+	vals.Set("next", "/home")
+
+	user, err := v.VerifyRequest(httptest.NewRequest("GET", "/login?"+vals.Encode(), nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.ID != 123456 || user.FirstName != "John" {
+		t.Fatalf("unexpected user %+v", user)
+	}
+}
@@ -0,0 +1,116 @@
+/*
+ * Echotron
+ * Copyright (C) 2018-2022 The Echotron Devs
+ *
+ * Echotron is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Echotron is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package echotron
+
+import (
+	"sort"
+	"time"
+)
+
+// mediaGroupKey identifies a media group being buffered by the Dispatcher.
+type mediaGroupKey struct {
+	chatID int64
+	id     string
+}
+
+// mediaGroup contains the updates of a media group received so far.
+type mediaGroup struct {
+	updates  []*Update
+	timer    *time.Timer
+	deadline time.Time
+}
+
+// SetMediaGroupWindow enables the aggregation of the messages belonging to the same media group, i.e. an album.
+// The Dispatcher buffers the messages of each media group until no new message of the group
+// is received for the given window, then it calls the Update method of the bot only once with an
+// Update whose MediaGroup field contains all the messages of the group, ordered by ID.
+// A window of 0, the default, disables the aggregation.
+func (d *Dispatcher) SetMediaGroupWindow(window time.Duration) {
+	d.mu.Lock()
+	d.groupWindow = window
+	d.mu.Unlock()
+}
+
+// mediaGroupMessage returns the message or the channel post of the update if it belongs to a media group.
+func mediaGroupMessage(u *Update) *Message {
+	switch {
+	case u.Message != nil && u.Message.MediaGroupID != "":
+		return u.Message
+	case u.ChannelPost != nil && u.ChannelPost.MediaGroupID != "":
+		return u.ChannelPost
+	default:
+		return nil
+	}
+}
+
+// bufferMediaGroup buffers the update if it belongs to a media group and the aggregation is enabled,
+// and returns true if so.
+func (d *Dispatcher) bufferMediaGroup(chatID int64, u *Update) bool {
+	msg := mediaGroupMessage(u)
+	if msg == nil {
+		return false
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.groupWindow <= 0 {
+		return false
+	}
+
+	key := mediaGroupKey{chatID, msg.MediaGroupID}
+	if g, ok := d.mediaGroups[key]; ok {
+		g.updates = append(g.updates, u)
+		g.deadline = time.Now().Add(d.groupWindow)
+		g.timer.Reset(d.groupWindow)
+		return true
+	}
+
+	g := &mediaGroup{
+		updates:  []*Update{u},
+		deadline: time.Now().Add(d.groupWindow),
+	}
+	g.timer = time.AfterFunc(d.groupWindow, func() { d.flushMediaGroup(key, g) })
+	d.mediaGroups[key] = g
+	return true
+}
+
+// flushMediaGroup passes the buffered media group to the bot as a single Update.
+// Reset doesn't stop a timer that has already fired, so the group is flushed only if it's still
+// the one buffered with its key and its window hasn't been extended in the meantime.
+func (d *Dispatcher) flushMediaGroup(key mediaGroupKey, g *mediaGroup) {
+	d.mu.Lock()
+	if d.mediaGroups[key] != g || time.Now().Before(g.deadline) {
+		d.mu.Unlock()
+		return
+	}
+	delete(d.mediaGroups, key)
+	d.mu.Unlock()
+
+	sort.Slice(g.updates, func(i, j int) bool {
+		return mediaGroupMessage(g.updates[i]).ID < mediaGroupMessage(g.updates[j]).ID
+	})
+
+	grouped := *g.updates[0]
+	for _, u := range g.updates {
+		grouped.MediaGroup = append(grouped.MediaGroup, mediaGroupMessage(u))
+	}
+
+	d.runUpdate(d.session(key.chatID, &grouped), key.chatID, &grouped)
+}
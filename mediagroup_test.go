@@ -0,0 +1,108 @@
+package echotron
+
+import (
+	"testing"
+	"time"
+)
+
+type groupRecorder chan *Update
+
+func (g groupRecorder) Update(u *Update) {
+	g <- u
+}
+
+func TestMediaGroupAggregation(t *testing.T) {
+	updates := make(groupRecorder, 10)
+	d := NewDispatcher("token", func(_ int64) Bot { return updates })
+	d.SetMediaGroupWindow(50 * time.Millisecond)
+
+	chat := &Chat{ID: 42}
+	d.updates <- &Update{ID: 3, Message: &Message{ID: 12, Chat: chat, MediaGroupID: "album"}}
+	d.updates <- &Update{ID: 1, Message: &Message{ID: 10, Chat: chat, MediaGroupID: "album"}}
+	d.updates <- &Update{ID: 4, Message: &Message{ID: 13, Chat: chat, Text: "not in album"}}
+	d.updates <- &Update{ID: 2, Message: &Message{ID: 11, Chat: chat, MediaGroupID: "album"}}
+
+	var single, grouped *Update
+	for i := 0; i < 2; i++ {
+		select {
+		case u := <-updates:
+			if u.MediaGroup != nil {
+				grouped = u
+			} else {
+				single = u
+			}
+		case <-time.After(time.Second):
+			t.Fatal("update not dispatched")
+		}
+	}
+
+	if single == nil || single.ID != 4 {
+		t.Fatalf("unexpected single update %+v", single)
+	}
+
+	if grouped == nil || grouped.ID != 1 || grouped.Message.ID != 10 || len(grouped.MediaGroup) != 3 {
+		t.Fatalf("unexpected grouped update %+v", grouped)
+	}
+	for i, m := range grouped.MediaGroup {
+		if m.ID != 10+i {
+			t.Fatalf("unexpected order of the media group: %d at %d", m.ID, i)
+		}
+	}
+
+	select {
+	case u := <-updates:
+		t.Fatalf("unexpected update %+v", u)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestMediaGroupDisabled(t *testing.T) {
+	updates := make(groupRecorder, 10)
+	d := NewDispatcher("token", func(_ int64) Bot { return updates })
+
+	d.updates <- &Update{Message: &Message{ID: 1, Chat: &Chat{ID: 1}, MediaGroupID: "album"}}
+
+	select {
+	case u := <-updates:
+		if u.MediaGroup != nil {
+			t.Fatal("unexpected media group")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("update not dispatched")
+	}
+}
+
+func TestMediaGroupStaleFlush(t *testing.T) {
+	updates := make(groupRecorder, 10)
+	d := NewDispatcher("token", func(_ int64) Bot { return updates })
+	d.SetMediaGroupWindow(time.Hour)
+
+	key := mediaGroupKey{42, "album"}
+	msg := func(id int) *Update {
+		return &Update{ID: id, Message: &Message{ID: id, Chat: &Chat{ID: 42}, MediaGroupID: "album"}}
+	}
+
+	d.bufferMediaGroup(42, msg(1))
+	d.mu.Lock()
+	current := d.mediaGroups[key]
+	d.mu.Unlock()
+
+	// A fire of the timer of a previous group with the same key, or of the current one
+	// before its window has elapsed, must not flush the current group.
+	d.flushMediaGroup(key, &mediaGroup{})
+	d.flushMediaGroup(key, current)
+
+	d.mu.Lock()
+	_, ok := d.mediaGroups[key]
+	d.mu.Unlock()
+	if !ok {
+		t.Fatal("media group flushed by a stale timer")
+	}
+
+	select {
+	case u := <-updates:
+		t.Fatalf("unexpected update %+v", u)
+	default:
+	}
+	current.timer.Stop()
+}
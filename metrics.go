@@ -0,0 +1,215 @@
+/*
+ * Echotron
+ * Copyright (C) 2018-2022 The Echotron Devs
+ *
+ * Echotron is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Echotron is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package echotron
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// requestKey identifies a group of requests to the Telegram API with the same method and outcome.
+type requestKey struct {
+	method string
+	code   string
+}
+
+// durationStat accumulates the count and the total duration of some operations.
+type durationStat struct {
+	count int64
+	sum   time.Duration
+}
+
+// Metrics is an Instrumentation that collects in memory the number, latency and outcome
+// of the requests to the Telegram API and of the updates handled by the Dispatcher.
+// It implements http.Handler to expose the collected metrics in the Prometheus text format.
+type Metrics struct {
+	requests        map[requestKey]int64
+	requestDuration map[string]*durationStat
+	updates         durationStat
+	inFlight        int64
+	pending         func() int
+	mu              sync.Mutex
+}
+
+// NewMetrics returns a new instance of the Metrics object.
+func NewMetrics() *Metrics {
+	return &Metrics{
+		requests:        make(map[requestKey]int64),
+		requestDuration: make(map[string]*durationStat),
+	}
+}
+
+// StartRequest is used to implement the Instrumentation interface.
+func (m *Metrics) StartRequest(method string) func(RequestResult) {
+	return func(res RequestResult) {
+		var code = "ok"
+
+		switch {
+		case res.Err != nil:
+			code = "error"
+		case res.ErrorCode != 0:
+			code = strconv.Itoa(res.ErrorCode)
+		}
+
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		m.requests[requestKey{method, code}]++
+		stat, ok := m.requestDuration[method]
+		if !ok {
+			stat = &durationStat{}
+			m.requestDuration[method] = stat
+		}
+		stat.count++
+		stat.sum += res.Duration
+	}
+}
+
+// StartUpdate is used to implement the Instrumentation interface.
+func (m *Metrics) StartUpdate(_ int64, _ *Update) func() {
+	var start = time.Now()
+
+	m.mu.Lock()
+	m.inFlight++
+	m.mu.Unlock()
+
+	return func() {
+		m.mu.Lock()
+		m.inFlight--
+		m.updates.count++
+		m.updates.sum += time.Since(start)
+		m.mu.Unlock()
+	}
+}
+
+// ObservePendingUpdates is used to implement the QueueInstrumentation interface.
+func (m *Metrics) ObservePendingUpdates(fn func() int) {
+	m.mu.Lock()
+	m.pending = fn
+	m.mu.Unlock()
+}
+
+// Requests returns the number of requests sent with the given Telegram API method,
+// summed over all their outcomes.
+func (m *Metrics) Requests(method string) (n int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for k, v := range m.requests {
+		if k.method == method {
+			n += v
+		}
+	}
+	return
+}
+
+// InFlightUpdates returns the number of updates that are currently being handled by the Update method of a Bot.
+func (m *Metrics) InFlightUpdates() int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.inFlight
+}
+
+// PendingUpdates returns the number of updates received by the Dispatcher and not yet dispatched to the bots.
+func (m *Metrics) PendingUpdates() int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.pendingUpdates()
+}
+
+// pendingUpdates reads the number of pending updates from the observed Dispatcher, if any, m.mu must be held.
+func (m *Metrics) pendingUpdates() int64 {
+	if m.pending == nil {
+		return 0
+	}
+	return int64(m.pending())
+}
+
+// ServeHTTP writes the collected metrics in the Prometheus text exposition format.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	m.WriteTo(w)
+}
+
+// WriteTo writes the collected metrics in the Prometheus text exposition format to w.
+func (m *Metrics) WriteTo(w io.Writer) (int64, error) {
+	var b bytes.Buffer
+
+	m.mu.Lock()
+
+	keys := make([]requestKey, 0, len(m.requests))
+	for k := range m.requests {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].method == keys[j].method {
+			return keys[i].code < keys[j].code
+		}
+		return keys[i].method < keys[j].method
+	})
+
+	methods := make([]string, 0, len(m.requestDuration))
+	for k := range m.requestDuration {
+		methods = append(methods, k)
+	}
+	sort.Strings(methods)
+
+	fmt.Fprintln(&b, "# HELP echotron_api_requests_total Number of requests sent to the Telegram API.")
+	fmt.Fprintln(&b, "# TYPE echotron_api_requests_total counter")
+	for _, k := range keys {
+		fmt.Fprintf(&b, "echotron_api_requests_total{method=\"%s\",code=\"%s\"} %d\n", escapeLabel(k.method), k.code, m.requests[k])
+	}
+
+	fmt.Fprintln(&b, "# HELP echotron_api_request_duration_seconds Latency of the requests sent to the Telegram API.")
+	fmt.Fprintln(&b, "# TYPE echotron_api_request_duration_seconds summary")
+	for _, method := range methods {
+		stat := m.requestDuration[method]
+		fmt.Fprintf(&b, "echotron_api_request_duration_seconds_sum{method=\"%s\"} %g\n", escapeLabel(method), stat.sum.Seconds())
+		fmt.Fprintf(&b, "echotron_api_request_duration_seconds_count{method=\"%s\"} %d\n", escapeLabel(method), stat.count)
+	}
+
+	fmt.Fprintln(&b, "# HELP echotron_update_duration_seconds Time spent in the Update method of the bots.")
+	fmt.Fprintln(&b, "# TYPE echotron_update_duration_seconds summary")
+	fmt.Fprintf(&b, "echotron_update_duration_seconds_sum %g\n", m.updates.sum.Seconds())
+	fmt.Fprintf(&b, "echotron_update_duration_seconds_count %d\n", m.updates.count)
+
+	fmt.Fprintln(&b, "# HELP echotron_updates_in_flight Number of updates currently being handled by the bots.")
+	fmt.Fprintln(&b, "# TYPE echotron_updates_in_flight gauge")
+	fmt.Fprintf(&b, "echotron_updates_in_flight %d\n", m.inFlight)
+
+	fmt.Fprintln(&b, "# HELP echotron_updates_pending Number of updates received by the Dispatcher and not yet dispatched to the bots.")
+	fmt.Fprintln(&b, "# TYPE echotron_updates_pending gauge")
+	fmt.Fprintf(&b, "echotron_updates_pending %d\n", m.pendingUpdates())
+
+	m.mu.Unlock()
+
+	return b.WriteTo(w)
+}
+
+// escapeLabel escapes a Prometheus label value.
+func escapeLabel(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
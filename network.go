@@ -20,12 +20,15 @@ package echotron
 
 import (
 	"bytes"
+	"encoding/json"
 	"io"
 	"mime/multipart"
 	"net/http"
 	"net/url"
+	"path"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
 // content is a struct which contains a file's name, its type and its data.
@@ -36,23 +39,17 @@ type content struct {
 }
 
 // sendGetRequest is used to send an HTTP GET request.
-func sendGetRequest(url string) ([]byte, error) {
-	resp, err := http.Get(url)
+func (a API) sendGetRequest(url string) ([]byte, error) {
+	request, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return []byte{}, err
 	}
-	defer resp.Body.Close()
 
-	data, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return []byte{}, err
-	}
-
-	return data, nil
+	return a.doRequest(request)
 }
 
 // sendPostRequest is used to send an HTTP POST request.
-func sendPostRequest(url string, files ...content) ([]byte, error) {
+func (a API) sendPostRequest(url string, files ...content) ([]byte, error) {
 	var buf = new(bytes.Buffer)
 	var w = multipart.NewWriter(buf)
 
@@ -72,22 +69,11 @@ func sendPostRequest(url string, files ...content) ([]byte, error) {
 	}
 	req.Header.Add("Content-Type", w.FormDataContentType())
 
-	var client = new(http.Client)
-	res, err := client.Do(req)
-	if err != nil {
-		return []byte{}, err
-	}
-	defer res.Body.Close()
-
-	cnt, err := io.ReadAll(res.Body)
-	if err != nil {
-		return []byte{}, err
-	}
-	return cnt, nil
+	return a.doRequest(req)
 }
 
 // sendPostForm is used to send an "application/x-www-form-urlencoded" through an HTTP POST request.
-func sendPostForm(reqURL string, keyVals map[string]string) ([]byte, error) {
+func (a API) sendPostForm(reqURL string, keyVals map[string]string) ([]byte, error) {
 	var form = make(url.Values)
 
 	for k, v := range keyVals {
@@ -101,18 +87,107 @@ func sendPostForm(reqURL string, keyVals map[string]string) ([]byte, error) {
 	request.PostForm = form
 	request.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 
-	var client http.Client
+	return a.doRequest(request)
+}
+
+// doRequest sends the HTTP request, reads the whole response body and reports the outcome of the call
+// to the Logger and the Instrumentation of the API, if any.
+func (a API) doRequest(request *http.Request) (cnt []byte, err error) {
+	var (
+		client http.Client
+		status int
+		finish func(RequestResult)
+		method = apiMethod(request.URL)
+		start  = time.Now()
+	)
+
+	if a.instrumentation != nil {
+		finish = a.instrumentation.StartRequest(method)
+	}
+
+	defer func() {
+		if err != nil {
+			err = redactError(err, a.token)
+		}
+
+		if a.logger == nil && finish == nil {
+			return
+		}
+
+		res := RequestResult{
+			Method:   method,
+			Duration: time.Since(start),
+			Status:   status,
+			Err:      err,
+		}
+
+		// Telegram answers the failed calls with a non-2xx status, so the successful
+		// responses are left for the caller to decode.
+		if err == nil && (status < 200 || status > 299) {
+			var base APIResponseBase
+			if json.Unmarshal(cnt, &base) == nil && !base.Ok {
+				res.ErrorCode = base.ErrorCode
+				res.Description = base.Description
+			}
+		}
+
+		a.logRequest(res)
+		if finish != nil {
+			finish(res)
+		}
+	}()
 
 	response, err := client.Do(request)
 	if err != nil {
 		return []byte{}, err
 	}
 	defer response.Body.Close()
+	status = response.StatusCode
 
-	content, err := io.ReadAll(response.Body)
+	cnt, err = io.ReadAll(response.Body)
 	if err != nil {
 		return []byte{}, err
 	}
 
-	return content, nil
+	return cnt, nil
+}
+
+// logRequest logs the outcome of a call to the Telegram API at the debug level.
+func (a API) logRequest(res RequestResult) {
+	if a.logger == nil {
+		return
+	}
+
+	var keyvals = []interface{}{
+		"method", res.Method,
+		"duration", res.Duration,
+		"status", res.Status,
+	}
+
+	switch {
+	case res.Err != nil:
+		a.logger.Debug("api call failed", append(keyvals, "error", res.Err)...)
+
+	case res.ErrorCode != 0:
+		a.logger.Debug("api call", append(keyvals, "error_code", res.ErrorCode, "description", res.Description)...)
+
+	default:
+		a.logger.Debug("api call", keyvals...)
+	}
+}
+
+// apiMethod returns the name of the Telegram API method called through the given URL.
+func apiMethod(u *url.URL) string {
+	if strings.HasPrefix(u.Path, "/file/") {
+		return "downloadFile"
+	}
+	return path.Base(u.Path)
+}
+
+// redactError removes the bot token from the URL reported by the HTTP client errors.
+func redactError(err error, token string) error {
+	if e, ok := err.(*url.Error); ok {
+		e.URL = redactToken(e.URL, token)
+	}
+	return err
 }
@@ -0,0 +1,30 @@
+package echotron
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"testing"
+)
+
+// mockRequest is a request received by the mock Bot API server.
+type mockRequest struct {
+	method string
+	query  url.Values
+}
+
+// newMockAPI returns an API instance talking to a local server which records
+// the received requests and replies to each of them with the given result.
+func newMockAPI(t *testing.T, result string) (API, <-chan mockRequest) {
+	reqs := make(chan mockRequest, 100)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseMultipartForm(1 << 20)
+		reqs <- mockRequest{method: path.Base(r.URL.Path), query: r.Form}
+		w.Write([]byte(`{"ok":true,"result":` + result + `}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	return API{token: "token", base: srv.URL + "/bottoken/"}, reqs
+}
@@ -97,19 +97,21 @@ type UpdateType string
 
 // These are all the possible types that a bot can be subscribed to.
 const (
-	MessageUpdate            UpdateType = "message"
-	EditedMessageUpdate                 = "edited_message"
-	ChannelPostUpdate                   = "channel_post"
-	EditedChannelPostUpdate             = "edited_channel_post"
-	InlineQueryUpdate                   = "inline_query"
-	ChosenInlineResultUpdate            = "chosen_inline_result"
-	CallbackQueryUpdate                 = "callback_query"
-	ShippingQueryUpdate                 = "shipping_query"
-	PreCheckoutQueryUpdate              = "pre_checkout_query"
-	PollUpdate                          = "poll"
-	PollAnswerUpdate                    = "poll_answer"
-	MyChatMemberUpdate                  = "my_chat_member"
-	ChatMemberUpdate                    = "chat_member"
+	MessageUpdate              UpdateType = "message"
+	EditedMessageUpdate                   = "edited_message"
+	ChannelPostUpdate                     = "channel_post"
+	EditedChannelPostUpdate               = "edited_channel_post"
+	MessageReactionUpdate                 = "message_reaction"
+	MessageReactionCountUpdate            = "message_reaction_count"
+	InlineQueryUpdate                     = "inline_query"
+	ChosenInlineResultUpdate              = "chosen_inline_result"
+	CallbackQueryUpdate                   = "callback_query"
+	ShippingQueryUpdate                   = "shipping_query"
+	PreCheckoutQueryUpdate                = "pre_checkout_query"
+	PollUpdate                            = "poll"
+	PollAnswerUpdate                      = "poll_answer"
+	MyChatMemberUpdate                    = "my_chat_member"
+	ChatMemberUpdate                      = "chat_member"
 )
 
 // ReplyMarkup is an interface for the various keyboard types.
@@ -119,10 +121,43 @@ type ReplyMarkup interface {
 
 // KeyboardButton represents a button in a keyboard.
 type KeyboardButton struct {
-	Text            string                  `json:"text"`
-	RequestContact  bool                    `json:"request_contact,omitempty"`
-	RequestLocation bool                    `json:"request_location,omitempty"`
-	RequestPoll     *KeyboardButtonPollType `json:"request_poll,omitempty"`
+	Text            string                      `json:"text"`
+	RequestContact  bool                        `json:"request_contact,omitempty"`
+	RequestLocation bool                        `json:"request_location,omitempty"`
+	RequestUsers    *KeyboardButtonRequestUsers `json:"request_users,omitempty"`
+	RequestChat     *KeyboardButtonRequestChat  `json:"request_chat,omitempty"`
+	RequestPoll     *KeyboardButtonPollType     `json:"request_poll,omitempty"`
+	WebApp          *WebAppInfo                 `json:"web_app,omitempty"`
+}
+
+// KeyboardButtonRequestUsers defines the criteria used to request suitable users.
+// The identifiers of the selected users will be shared with the bot in a UsersShared service message when the corresponding button is pressed.
+// The pointer fields are optional filters, a nil value applies no restriction.
+type KeyboardButtonRequestUsers struct {
+	RequestID       int   `json:"request_id"`
+	UserIsBot       *bool `json:"user_is_bot,omitempty"`
+	UserIsPremium   *bool `json:"user_is_premium,omitempty"`
+	MaxQuantity     int   `json:"max_quantity,omitempty"`
+	RequestName     bool  `json:"request_name,omitempty"`
+	RequestUsername bool  `json:"request_username,omitempty"`
+	RequestPhoto    bool  `json:"request_photo,omitempty"`
+}
+
+// KeyboardButtonRequestChat defines the criteria used to request a suitable chat.
+// The identifier of the selected chat will be shared with the bot in a ChatShared service message when the corresponding button is pressed.
+// The pointer fields are optional filters, a nil value applies no restriction.
+type KeyboardButtonRequestChat struct {
+	RequestID               int                      `json:"request_id"`
+	ChatIsChannel           bool                     `json:"chat_is_channel"`
+	ChatIsForum             *bool                    `json:"chat_is_forum,omitempty"`
+	ChatHasUsername         *bool                    `json:"chat_has_username,omitempty"`
+	ChatIsCreated           bool                     `json:"chat_is_created,omitempty"`
+	UserAdministratorRights *ChatAdministratorRights `json:"user_administrator_rights,omitempty"`
+	BotAdministratorRights  *ChatAdministratorRights `json:"bot_administrator_rights,omitempty"`
+	BotIsMember             bool                     `json:"bot_is_member,omitempty"`
+	RequestTitle            bool                     `json:"request_title,omitempty"`
+	RequestUsername         bool                     `json:"request_username,omitempty"`
+	RequestPhoto            bool                     `json:"request_photo,omitempty"`
 }
 
 // KeyboardButtonPollType represents type of a poll, which is allowed to be created and sent when the corresponding button is pressed.
@@ -164,6 +199,7 @@ type InlineKeyboardButton struct {
 	SwitchInlineQueryCurrentChat string        `json:"switch_inline_query_current_chat,omitempty"`
 	CallbackGame                 *CallbackGame `json:"callback_game,omitempty"`
 	Pay                          bool          `json:"pay,omitempty"`
+	WebApp                       *WebAppInfo   `json:"web_app,omitempty"`
 }
 
 // InlineKeyboardMarkup represents an inline keyboard.
@@ -203,38 +239,50 @@ type WebhookOptions struct {
 
 // BaseOptions contains the optional parameters used frequently in some Telegram API methods.
 type BaseOptions struct {
-	DisableNotification      bool        `query:"disable_notification"`
-	ProtectContent           bool        `query:"protect_content"`
-	ReplyToMessageID         int         `query:"reply_to_message_id"`
-	AllowSendingWithoutReply bool        `query:"allow_sending_without_reply"`
-	ReplyMarkup              ReplyMarkup `query:"reply_markup"`
-}
-
-// MessageOptions contains the optional parameters used by some Telegram API methods.
-type MessageOptions struct {
-	ParseMode                ParseMode       `query:"parse_mode"`
-	Entities                 []MessageEntity `query:"entities"`
-	DisableWebPagePreview    bool            `query:"disable_web_page_preview"`
+	MessageThreadID          int             `query:"message_thread_id"`
 	DisableNotification      bool            `query:"disable_notification"`
 	ProtectContent           bool            `query:"protect_content"`
 	ReplyToMessageID         int             `query:"reply_to_message_id"`
 	AllowSendingWithoutReply bool            `query:"allow_sending_without_reply"`
+	ReplyParameters          ReplyParameters `query:"reply_parameters"`
 	ReplyMarkup              ReplyMarkup     `query:"reply_markup"`
 }
 
+// MessageOptions contains the optional parameters used by some Telegram API methods.
+type MessageOptions struct {
+	MessageThreadID          int                `query:"message_thread_id"`
+	ParseMode                ParseMode          `query:"parse_mode"`
+	Entities                 []MessageEntity    `query:"entities"`
+	DisableWebPagePreview    bool               `query:"disable_web_page_preview"`
+	LinkPreviewOptions       LinkPreviewOptions `query:"link_preview_options"`
+	DisableNotification      bool               `query:"disable_notification"`
+	ProtectContent           bool               `query:"protect_content"`
+	ReplyToMessageID         int                `query:"reply_to_message_id"`
+	AllowSendingWithoutReply bool               `query:"allow_sending_without_reply"`
+	ReplyParameters          ReplyParameters    `query:"reply_parameters"`
+	ReplyMarkup              ReplyMarkup        `query:"reply_markup"`
+}
+
 // PinMessageOptions contains the optional parameters used by the PinChatMember method.
 type PinMessageOptions struct {
 	DisableNotification bool `query:"disable_notification"`
 }
 
-// ForwardOptions contains the optional parameters used by the ForwardMessage method.
+// ChatActionOptions contains the optional parameters used by the SendChatAction method.
+type ChatActionOptions struct {
+	MessageThreadID int `query:"message_thread_id"`
+}
+
+// ForwardOptions contains the optional parameters used by the ForwardMessage and ForwardMessages methods.
 type ForwardOptions struct {
+	MessageThreadID     int  `query:"message_thread_id"`
 	DisableNotification bool `query:"disable_notification"`
 	ProtectContent      bool `query:"protect_content"`
 }
 
 // CopyOptions contains the optional parameters used by the CopyMessage method.
 type CopyOptions struct {
+	MessageThreadID          int             `query:"message_thread_id"`
 	ParseMode                ParseMode       `query:"parse_mode"`
 	Caption                  string          `query:"caption"`
 	CaptionEntities          []MessageEntity `query:"caption_entities"`
@@ -242,9 +290,18 @@ type CopyOptions struct {
 	ProtectContent           bool            `query:"protect_content"`
 	ReplyToMessageID         int             `query:"reply_to_message_id"`
 	AllowSendingWithoutReply bool            `query:"allow_sending_without_reply"`
+	ReplyParameters          ReplyParameters `query:"reply_parameters"`
 	ReplyMarkup              ReplyMarkup     `query:"reply_markup"`
 }
 
+// CopyMessagesOptions contains the optional parameters used by the CopyMessages method.
+type CopyMessagesOptions struct {
+	MessageThreadID     int  `query:"message_thread_id"`
+	DisableNotification bool `query:"disable_notification"`
+	ProtectContent      bool `query:"protect_content"`
+	RemoveCaption       bool `query:"remove_caption"`
+}
+
 // InputFile is a struct which contains data about a file to be sent.
 type InputFile struct {
 	id      string
@@ -270,6 +327,7 @@ func NewInputFileBytes(fileName string, content []byte) InputFile {
 
 // PhotoOptions contains the optional parameters used by the SendPhoto method.
 type PhotoOptions struct {
+	MessageThreadID          int             `query:"message_thread_id"`
 	ParseMode                ParseMode       `query:"parse_mode"`
 	Caption                  string          `query:"caption"`
 	CaptionEntities          []MessageEntity `query:"caption_entities"`
@@ -277,11 +335,13 @@ type PhotoOptions struct {
 	ProtectContent           bool            `query:"protect_content"`
 	ReplyToMessageID         int             `query:"reply_to_message_id"`
 	AllowSendingWithoutReply bool            `query:"allow_sending_without_reply"`
+	ReplyParameters          ReplyParameters `query:"reply_parameters"`
 	ReplyMarkup              ReplyMarkup     `query:"reply_markup"`
 }
 
 // AudioOptions contains the optional parameters used by the SendAudio method.
 type AudioOptions struct {
+	MessageThreadID          int             `query:"message_thread_id"`
 	ParseMode                ParseMode       `query:"parse_mode"`
 	Caption                  string          `query:"caption"`
 	CaptionEntities          []MessageEntity `query:"caption_entities"`
@@ -289,29 +349,33 @@ type AudioOptions struct {
 	Performer                string          `query:"performer"`
 	Title                    string          `query:"title"`
 	Thumb                    InputFile
-	DisableNotification      bool        `query:"disable_notification"`
-	ProtectContent           bool        `query:"protect_content"`
-	ReplyToMessageID         int         `query:"reply_to_message_id"`
-	AllowSendingWithoutReply bool        `query:"allow_sending_without_reply"`
-	ReplyMarkup              ReplyMarkup `query:"reply_markup"`
+	DisableNotification      bool            `query:"disable_notification"`
+	ProtectContent           bool            `query:"protect_content"`
+	ReplyToMessageID         int             `query:"reply_to_message_id"`
+	AllowSendingWithoutReply bool            `query:"allow_sending_without_reply"`
+	ReplyParameters          ReplyParameters `query:"reply_parameters"`
+	ReplyMarkup              ReplyMarkup     `query:"reply_markup"`
 }
 
 // DocumentOptions contains the optional parameters used by the SendDocument method.
 type DocumentOptions struct {
+	MessageThreadID             int             `query:"message_thread_id"`
 	ParseMode                   ParseMode       `query:"parse_mode"`
 	Caption                     string          `query:"caption"`
 	CaptionEntities             []MessageEntity `query:"caption_entities"`
 	DisableContentTypeDetection bool            `query:"disable_content_type_detection"`
 	Thumb                       InputFile
-	DisableNotification         bool        `query:"disable_notification"`
-	ProtectContent              bool        `query:"protect_content"`
-	ReplyToMessageID            int         `query:"reply_to_message_id"`
-	AllowSendingWithoutReply    bool        `query:"allow_sending_without_reply"`
-	ReplyMarkup                 ReplyMarkup `query:"reply_markup"`
+	DisableNotification         bool            `query:"disable_notification"`
+	ProtectContent              bool            `query:"protect_content"`
+	ReplyToMessageID            int             `query:"reply_to_message_id"`
+	AllowSendingWithoutReply    bool            `query:"allow_sending_without_reply"`
+	ReplyParameters             ReplyParameters `query:"reply_parameters"`
+	ReplyMarkup                 ReplyMarkup     `query:"reply_markup"`
 }
 
 // VideoOptions contains the optional parameters used by the SendVideo method.
 type VideoOptions struct {
+	MessageThreadID          int             `query:"message_thread_id"`
 	ParseMode                ParseMode       `query:"parse_mode"`
 	Caption                  string          `query:"caption"`
 	CaptionEntities          []MessageEntity `query:"caption_entities"`
@@ -319,16 +383,18 @@ type VideoOptions struct {
 	Width                    int             `query:"width"`
 	Height                   int             `query:"height"`
 	Thumb                    InputFile
-	SupportsStreaming        bool        `query:"supports_streaming"`
-	DisableNotification      bool        `query:"disable_notification"`
-	ProtectContent           bool        `query:"protect_content"`
-	ReplyToMessageID         int         `query:"reply_to_message_id"`
-	AllowSendingWithoutReply bool        `query:"allow_sending_without_reply"`
-	ReplyMarkup              ReplyMarkup `query:"reply_markup"`
+	SupportsStreaming        bool            `query:"supports_streaming"`
+	DisableNotification      bool            `query:"disable_notification"`
+	ProtectContent           bool            `query:"protect_content"`
+	ReplyToMessageID         int             `query:"reply_to_message_id"`
+	AllowSendingWithoutReply bool            `query:"allow_sending_without_reply"`
+	ReplyParameters          ReplyParameters `query:"reply_parameters"`
+	ReplyMarkup              ReplyMarkup     `query:"reply_markup"`
 }
 
 // AnimationOptions contains the optional parameters used by the SendAnimation method.
 type AnimationOptions struct {
+	MessageThreadID          int             `query:"message_thread_id"`
 	ParseMode                ParseMode       `query:"parse_mode"`
 	Caption                  string          `query:"caption"`
 	CaptionEntities          []MessageEntity `query:"caption_entities"`
@@ -336,15 +402,17 @@ type AnimationOptions struct {
 	Width                    int             `query:"width"`
 	Height                   int             `query:"height"`
 	Thumb                    InputFile
-	DisableNotification      bool        `query:"disable_notification"`
-	ProtectContent           bool        `query:"protect_content"`
-	ReplyToMessageID         int         `query:"reply_to_message_id"`
-	AllowSendingWithoutReply bool        `query:"allow_sending_without_reply"`
-	ReplyMarkup              ReplyMarkup `query:"reply_markup"`
+	DisableNotification      bool            `query:"disable_notification"`
+	ProtectContent           bool            `query:"protect_content"`
+	ReplyToMessageID         int             `query:"reply_to_message_id"`
+	AllowSendingWithoutReply bool            `query:"allow_sending_without_reply"`
+	ReplyParameters          ReplyParameters `query:"reply_parameters"`
+	ReplyMarkup              ReplyMarkup     `query:"reply_markup"`
 }
 
 // VoiceOptions contains the optional parameters used by the SendVoice method.
 type VoiceOptions struct {
+	MessageThreadID          int             `query:"message_thread_id"`
 	ParseMode                ParseMode       `query:"parse_mode"`
 	Caption                  string          `query:"caption"`
 	CaptionEntities          []MessageEntity `query:"caption_entities"`
@@ -353,40 +421,47 @@ type VoiceOptions struct {
 	ProtectContent           bool            `query:"protect_content"`
 	ReplyToMessageID         int             `query:"reply_to_message_id"`
 	AllowSendingWithoutReply bool            `query:"allow_sending_without_reply"`
+	ReplyParameters          ReplyParameters `query:"reply_parameters"`
 	ReplyMarkup              ReplyMarkup     `query:"reply_markup"`
 }
 
 // VideoNoteOptions contains the optional parameters used by the SendVideoNote method.
 type VideoNoteOptions struct {
+	MessageThreadID          int `query:"message_thread_id"`
 	Duration                 int `query:"duration"`
 	Length                   int `query:"length"`
 	Thumb                    InputFile
-	DisableNotification      bool        `query:"disable_notification"`
-	ProtectContent           bool        `query:"protect_content"`
-	ReplyToMessageID         int         `query:"reply_to_message_id"`
-	AllowSendingWithoutReply bool        `query:"allow_sending_without_reply"`
-	ReplyMarkup              ReplyMarkup `query:"reply_markup"`
+	DisableNotification      bool            `query:"disable_notification"`
+	ProtectContent           bool            `query:"protect_content"`
+	ReplyToMessageID         int             `query:"reply_to_message_id"`
+	AllowSendingWithoutReply bool            `query:"allow_sending_without_reply"`
+	ReplyParameters          ReplyParameters `query:"reply_parameters"`
+	ReplyMarkup              ReplyMarkup     `query:"reply_markup"`
 }
 
 // MediaGroupOptions contains the optional parameters used by the SendMediaGroup method.
 type MediaGroupOptions struct {
-	DisableNotification      bool `query:"disable_notification"`
-	ProtectContent           bool `query:"protect_content"`
-	ReplyToMessageID         int  `query:"reply_to_message_id"`
-	AllowSendingWithoutReply bool `query:"allow_sending_without_reply"`
+	MessageThreadID          int             `query:"message_thread_id"`
+	DisableNotification      bool            `query:"disable_notification"`
+	ProtectContent           bool            `query:"protect_content"`
+	ReplyToMessageID         int             `query:"reply_to_message_id"`
+	AllowSendingWithoutReply bool            `query:"allow_sending_without_reply"`
+	ReplyParameters          ReplyParameters `query:"reply_parameters"`
 }
 
 // LocationOptions contains the optional parameters used by the SendLocation method.
 type LocationOptions struct {
-	HorizontalAccuracy       float64     `query:"horizontal_accuracy"`
-	LivePeriod               int         `query:"live_period"`
-	Heading                  int         `query:"heading"`
-	ProximityAlertRadius     int         `query:"proximity_alert_radius"`
-	DisableNotification      bool        `query:"disable_notification"`
-	ProtectContent           bool        `query:"protect_content"`
-	ReplyToMessageID         int         `query:"reply_to_message_id"`
-	AllowSendingWithoutReply bool        `query:"allow_sending_without_reply"`
-	ReplyMarkup              ReplyMarkup `query:"reply_markup"`
+	MessageThreadID          int             `query:"message_thread_id"`
+	HorizontalAccuracy       float64         `query:"horizontal_accuracy"`
+	LivePeriod               int             `query:"live_period"`
+	Heading                  int             `query:"heading"`
+	ProximityAlertRadius     int             `query:"proximity_alert_radius"`
+	DisableNotification      bool            `query:"disable_notification"`
+	ProtectContent           bool            `query:"protect_content"`
+	ReplyToMessageID         int             `query:"reply_to_message_id"`
+	AllowSendingWithoutReply bool            `query:"allow_sending_without_reply"`
+	ReplyParameters          ReplyParameters `query:"reply_parameters"`
+	ReplyMarkup              ReplyMarkup     `query:"reply_markup"`
 }
 
 // EditLocationOptions contains the optional parameters used by the EditMessageLiveLocation method.
@@ -399,30 +474,35 @@ type EditLocationOptions struct {
 
 // VenueOptions contains the optional parameters used by the SendVenue method.
 type VenueOptions struct {
-	FoursquareID             string      `query:"foursquare_id"`
-	FoursquareType           string      `query:"foursquare_type"`
-	GooglePlaceID            string      `query:"google_place_id"`
-	GooglePlaceType          string      `query:"google_place_type"`
-	DisableNotification      bool        `query:"disable_notification"`
-	ProtectContent           bool        `query:"protect_content"`
-	ReplyToMessageID         int         `query:"reply_to_message_id"`
-	AllowSendingWithoutReply bool        `query:"allow_sending_without_reply"`
-	ReplyMarkup              ReplyMarkup `query:"reply_markup"`
+	MessageThreadID          int             `query:"message_thread_id"`
+	FoursquareID             string          `query:"foursquare_id"`
+	FoursquareType           string          `query:"foursquare_type"`
+	GooglePlaceID            string          `query:"google_place_id"`
+	GooglePlaceType          string          `query:"google_place_type"`
+	DisableNotification      bool            `query:"disable_notification"`
+	ProtectContent           bool            `query:"protect_content"`
+	ReplyToMessageID         int             `query:"reply_to_message_id"`
+	AllowSendingWithoutReply bool            `query:"allow_sending_without_reply"`
+	ReplyParameters          ReplyParameters `query:"reply_parameters"`
+	ReplyMarkup              ReplyMarkup     `query:"reply_markup"`
 }
 
 // ContactOptions contains the optional parameters used by the SendContact method.
 type ContactOptions struct {
-	LastName                 string      `query:"last_name"`
-	VCard                    string      `query:"vcard"`
-	DisableNotification      bool        `query:"disable_notification"`
-	ProtectContent           bool        `query:"protect_content"`
-	ReplyToMessageID         int         `query:"reply_to_message_id"`
-	AllowSendingWithoutReply bool        `query:"allow_sending_without_reply"`
-	ReplyMarkup              ReplyMarkup `query:"reply_markup"`
+	MessageThreadID          int             `query:"message_thread_id"`
+	LastName                 string          `query:"last_name"`
+	VCard                    string          `query:"vcard"`
+	DisableNotification      bool            `query:"disable_notification"`
+	ProtectContent           bool            `query:"protect_content"`
+	ReplyToMessageID         int             `query:"reply_to_message_id"`
+	AllowSendingWithoutReply bool            `query:"allow_sending_without_reply"`
+	ReplyParameters          ReplyParameters `query:"reply_parameters"`
+	ReplyMarkup              ReplyMarkup     `query:"reply_markup"`
 }
 
 // PollOptions contains the optional parameters used by the SendPoll method.
 type PollOptions struct {
+	MessageThreadID          int             `query:"message_thread_id"`
 	IsAnonymous              bool            `query:"is_anonymous"`
 	Type                     PollType        `query:"type"`
 	AllowsMultipleAnswers    bool            `query:"allows_multiple_answers"`
@@ -437,6 +517,7 @@ type PollOptions struct {
 	ProtectContent           bool            `query:"protect_content"`
 	ReplyToMessageID         int             `query:"reply_to_message_id"`
 	AllowSendingWithoutReply bool            `query:"allow_sending_without_reply"`
+	ReplyParameters          ReplyParameters `query:"reply_parameters"`
 	ReplyMarkup              ReplyMarkup     `query:"reply_markup"`
 }
 
@@ -469,6 +550,7 @@ type PromoteOptions struct {
 	CanChangeInfo       bool `query:"can_change_info"`
 	CanInviteUsers      bool `query:"can_invite_users"`
 	CanPinMessages      bool `query:"can_pin_messages"`
+	CanManageTopics     bool `query:"can_manage_topics"`
 }
 
 // UserProfileOptions contains the optional parameters used by the GetUserProfilePhotos method.
@@ -515,6 +597,7 @@ type MessageTextOptions struct {
 	ParseMode             ParseMode            `query:"parse_mode"`
 	Entities              []MessageEntity      `query:"entities"`
 	DisableWebPagePreview bool                 `query:"disable_web_page_preview"`
+	LinkPreviewOptions    LinkPreviewOptions   `query:"link_preview_options"`
 	ReplyMarkup           InlineKeyboardMarkup `query:"reply_markup"`
 }
 
@@ -536,3 +619,25 @@ type CommandOptions struct {
 	Scope        BotCommandScope `query:"scope"`
 	LanguageCode string          `query:"language_code"`
 }
+
+// SetChatMenuButtonOptions contains the optional parameters used by the SetChatMenuButton method.
+type SetChatMenuButtonOptions struct {
+	ChatID     int64      `query:"chat_id"`
+	MenuButton MenuButton `query:"menu_button"`
+}
+
+// GetChatMenuButtonOptions contains the optional parameters used by the GetChatMenuButton method.
+type GetChatMenuButtonOptions struct {
+	ChatID int64 `query:"chat_id"`
+}
+
+// SetMyDefaultAdministratorRightsOptions contains the optional parameters used by the SetMyDefaultAdministratorRights method.
+type SetMyDefaultAdministratorRightsOptions struct {
+	Rights      ChatAdministratorRights `query:"rights"`
+	ForChannels bool                    `query:"for_channels"`
+}
+
+// GetMyDefaultAdministratorRightsOptions contains the optional parameters used by the GetMyDefaultAdministratorRights method.
+type GetMyDefaultAdministratorRightsOptions struct {
+	ForChannels bool `query:"for_channels"`
+}
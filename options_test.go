@@ -1,6 +1,9 @@
 package echotron
 
-import "testing"
+import (
+	"encoding/json"
+	"testing"
+)
 
 func TestReplyKeyboardMarkupImplementsReplyMarkup(_ *testing.T) {
 	i := ReplyKeyboardMarkup{}
@@ -21,3 +24,85 @@ func TestForceReplyImplementsReplyMarkup(_ *testing.T) {
 	i := ForceReply{}
 	i.ImplementsReplyMarkup()
 }
+
+func TestReplyParametersOption(t *testing.T) {
+	a, reqs := newMockAPI(t, `{"message_id":2}`)
+
+	_, err := a.SendMessage("hello", chatID, &MessageOptions{
+		LinkPreviewOptions: LinkPreviewOptions{URL: "https://example.com", PreferSmallMedia: true, ShowAboveText: true},
+		ReplyParameters: ReplyParameters{
+			MessageID: 1,
+			ChatID:    -100,
+			Quote:     "quoted text",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := <-reqs
+
+	var reply ReplyParameters
+	if err = json.Unmarshal([]byte(req.query.Get("reply_parameters")), &reply); err != nil {
+		t.Fatal(err)
+	}
+	if reply.MessageID != 1 || reply.ChatID != -100 || reply.Quote != "quoted text" {
+		t.Fatalf("unexpected reply_parameters %+v", reply)
+	}
+
+	var preview LinkPreviewOptions
+	if err = json.Unmarshal([]byte(req.query.Get("link_preview_options")), &preview); err != nil {
+		t.Fatal(err)
+	}
+	if preview.URL != "https://example.com" || !preview.PreferSmallMedia || !preview.ShowAboveText {
+		t.Fatalf("unexpected link_preview_options %+v", preview)
+	}
+}
+
+func TestLegacyReplyOptions(t *testing.T) {
+	a, reqs := newMockAPI(t, `{"message_id":2}`)
+
+	_, err := a.SendMessage("hello", chatID, &MessageOptions{ReplyToMessageID: 1, DisableWebPagePreview: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := <-reqs
+	if req.query.Get("reply_to_message_id") != "1" || req.query.Get("disable_web_page_preview") != "true" {
+		t.Fatalf("legacy options not sent: %v", req.query)
+	}
+	if req.query.Has("reply_parameters") || req.query.Has("link_preview_options") {
+		t.Fatalf("unexpected new options: %v", req.query)
+	}
+}
+
+func TestMessageQuoteAndExternalReply(t *testing.T) {
+	var msg Message
+
+	err := json.Unmarshal([]byte(`{
+		"message_id": 2,
+		"quote": {"text": "quoted text", "position": 5, "is_manual": true},
+		"external_reply": {
+			"origin": {"type": "channel", "date": 1700000000, "chat": {"id": -100, "type": "channel"}, "message_id": 7},
+			"chat": {"id": -100, "type": "channel"},
+			"message_id": 7,
+			"photo": [{"file_id": "a", "file_unique_id": "b", "width": 90, "height": 90}]
+		},
+		"link_preview_options": {"is_disabled": true}
+	}`), &msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if msg.Quote == nil || msg.Quote.Text != "quoted text" || msg.Quote.Position != 5 || !msg.Quote.IsManual {
+		t.Fatalf("unexpected quote %+v", msg.Quote)
+	}
+
+	ext := msg.ExternalReply
+	if ext == nil || ext.Origin == nil || ext.Origin.Type != ChannelOrigin || ext.Origin.MessageID != 7 || len(ext.Photo) != 1 {
+		t.Fatalf("unexpected external reply %+v", ext)
+	}
+	if msg.LinkPreviewOptions == nil || !msg.LinkPreviewOptions.IsDisabled {
+		t.Fatalf("unexpected link preview options %+v", msg.LinkPreviewOptions)
+	}
+}
@@ -0,0 +1,174 @@
+/*
+ * Echotron
+ * Copyright (C) 2018-2022 The Echotron Devs
+ *
+ * Echotron is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Echotron is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package echotron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// noopPage is the page number encoded in the callback data of the page indicator button.
+const noopPage = -1
+
+// PageCount returns the total number of items shown by a Paginator.
+type PageCount func() (int, error)
+
+// PageSource returns the buttons of at most limit items starting from offset.
+type PageSource func(offset, limit int) ([]InlineKeyboardButton, error)
+
+// Paginator renders a list of items as an inline keyboard split in pages
+// with navigation buttons, and handles the navigation callback queries
+// by editing the keyboard of the original message, sent either in a chat or in inline mode.
+// The navigation buttons carry callback data in the format used by CallbackCodec,
+// so a Paginator can be registered in a CallbackRouter with HandleRoute.
+type Paginator struct {
+	api    API
+	route  string
+	count  PageCount
+	source PageSource
+	// PageSize is the number of items shown in each page, 10 by default.
+	PageSize int
+	// Columns is the number of items shown in each row, 1 by default.
+	Columns int
+	// PrevText and NextText are the texts of the navigation buttons.
+	PrevText string
+	NextText string
+}
+
+// NewPaginator returns a new instance of the Paginator object.
+// The route is the prefix of the callback data of the navigation buttons
+// and must be unique among the ones used by the bot.
+// The count function is called before source, so that only the items of an existing page are requested.
+func NewPaginator(api API, route string, count PageCount, source PageSource) *Paginator {
+	return &Paginator{
+		api:      api,
+		route:    route,
+		count:    count,
+		source:   source,
+		PageSize: 10,
+		Columns:  1,
+		PrevText: "«",
+		NextText: "»",
+	}
+}
+
+// Route returns the route of the callback data of the navigation buttons.
+func (p *Paginator) Route() string {
+	return p.route
+}
+
+// Page returns the inline keyboard showing the given page, starting from 0.
+// Pages out of range are clamped to the first or the last one.
+func (p *Paginator) Page(page int) (InlineKeyboardMarkup, error) {
+	size := p.PageSize
+	if size <= 0 {
+		size = 10
+	}
+
+	total, err := p.count()
+	if err != nil {
+		return InlineKeyboardMarkup{}, err
+	}
+
+	// The page comes from the callback data, so it's clamped before computing the offset.
+	pages := (total + size - 1) / size
+	if page >= pages {
+		page = pages - 1
+	}
+	if page < 0 {
+		page = 0
+	}
+
+	var items []InlineKeyboardButton
+	if pages > 0 {
+		if items, err = p.source(page*size, size); err != nil {
+			return InlineKeyboardMarkup{}, err
+		}
+	}
+
+	kbd := NewInlineKeyboard().Columns(p.Columns, items...)
+
+	if pages > 1 {
+		var nav []InlineKeyboardButton
+
+		if page > 0 {
+			nav = append(nav, CallbackButton(p.PrevText, p.data(page-1)))
+		}
+		nav = append(nav, CallbackButton(fmt.Sprintf("%d/%d", page+1, pages), p.data(noopPage)))
+		if page < pages-1 {
+			nav = append(nav, CallbackButton(p.NextText, p.data(page+1)))
+		}
+
+		kbd.Row(nav...)
+	}
+
+	return kbd.Build()
+}
+
+// Match returns the page requested by the callback data and true if it comes from
+// one of the navigation buttons of the paginator.
+func (p *Paginator) Match(data string) (int, bool) {
+	if !strings.HasPrefix(data, p.route+string(inlinePayload)) {
+		return 0, false
+	}
+
+	page, err := strconv.Atoi(data[len(p.route)+1:])
+	if err != nil {
+		return 0, false
+	}
+	return page, true
+}
+
+// HandleCallback edits the message the callback query originated from to show the requested page.
+// It can be used as a CallbackHandler, which takes care of answering the query.
+func (p *Paginator) HandleCallback(c *CallbackContext) (*CallbackQueryOptions, error) {
+	page, ok := p.Match(c.Query.Data)
+	if !ok || page == noopPage {
+		return nil, nil
+	}
+
+	kbd, err := p.Page(page)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = c.API.EditMessageReplyMarkup(c.Query.MessageIDOptions(), &MessageReplyMarkup{ReplyMarkup: kbd})
+	return nil, err
+}
+
+// Handle handles the callback query if it comes from one of the navigation buttons of the paginator,
+// answering it afterwards, and returns true if the query has been used.
+func (p *Paginator) Handle(q *CallbackQuery) (bool, error) {
+	if _, ok := p.Match(q.Data); !ok {
+		return false, nil
+	}
+
+	c := &CallbackContext{API: p.api, Query: q}
+	opts, err := p.HandleCallback(c)
+	if _, aerr := c.Answer(opts); err == nil {
+		err = aerr
+	}
+	return true, err
+}
+
+// data returns the callback data of the navigation button pointing to the given page.
+func (p *Paginator) data(page int) string {
+	return p.route + string(inlinePayload) + strconv.Itoa(page)
+}
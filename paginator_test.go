@@ -0,0 +1,123 @@
+package echotron
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func numbersCount(n int) PageCount {
+	return func() (int, error) {
+		return n, nil
+	}
+}
+
+func numbersSource(n int) PageSource {
+	return func(offset, limit int) (buttons []InlineKeyboardButton, err error) {
+		for i := offset; i < offset+limit && i < n; i++ {
+			buttons = append(buttons, CallbackButton(fmt.Sprint(i), fmt.Sprint("n", i)))
+		}
+		return buttons, nil
+	}
+}
+
+func TestPaginatorPage(t *testing.T) {
+	p := NewPaginator(API{}, "num", numbersCount(25), numbersSource(25))
+	p.Columns = 5
+
+	kbd, err := p.Page(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(kbd.InlineKeyboard) != 3 {
+		t.Fatalf("expected 3 rows, got %d", len(kbd.InlineKeyboard))
+	}
+
+	nav := kbd.InlineKeyboard[2]
+	if len(nav) != 3 || nav[0].CallbackData != "num:0" || nav[1].Text != "2/3" || nav[2].CallbackData != "num:2" {
+		t.Fatalf("unexpected navigation row %+v", nav)
+	}
+
+	// Out of range pages show the last one.
+	if kbd, _ = p.Page(10); len(kbd.InlineKeyboard[0]) != 5 || kbd.InlineKeyboard[0][0].Text != "20" {
+		t.Fatalf("unexpected last page %+v", kbd.InlineKeyboard)
+	}
+
+	if page, ok := p.Match("num:2"); !ok || page != 2 {
+		t.Fatalf("unexpected match %d %t", page, ok)
+	}
+	if _, ok := p.Match("number:2"); ok {
+		t.Fatal("unexpected match")
+	}
+}
+
+func TestPaginatorHandle(t *testing.T) {
+	a, reqs := newMockAPI(t, "true")
+	p := NewPaginator(a, "num", numbersCount(25), numbersSource(25))
+
+	if ok, err := p.Handle(&CallbackQuery{ID: "1", Data: "num:2", InlineMessageID: "inline"}); !ok || err != nil {
+		t.Fatalf("unexpected result %t %v", ok, err)
+	}
+
+	edit := <-reqs
+	if edit.method != "editMessageReplyMarkup" || edit.query.Get("inline_message_id") != "inline" {
+		t.Fatalf("unexpected request %+v", edit)
+	}
+
+	var kbd InlineKeyboardMarkup
+	if err := json.Unmarshal([]byte(edit.query.Get("reply_markup")), &kbd); err != nil {
+		t.Fatal(err)
+	}
+	if kbd.InlineKeyboard[0][0].Text != "20" {
+		t.Fatalf("unexpected keyboard %+v", kbd)
+	}
+
+	if answer := <-reqs; answer.method != "answerCallbackQuery" || answer.query.Get("callback_query_id") != "1" {
+		t.Fatalf("unexpected request %+v", answer)
+	}
+
+	r := NewCallbackRouter(a)
+	r.HandleRoute(p.Route(), p.HandleCallback)
+
+	q := &CallbackQuery{ID: "2", Data: "num:1", Message: &Message{ID: 3, Chat: &Chat{ID: 42}}}
+	if ok, err := r.Route(q); !ok || err != nil {
+		t.Fatalf("unexpected result %t %v", ok, err)
+	}
+	if edit = <-reqs; edit.query.Get("chat_id") != "42" || edit.query.Get("message_id") != "3" {
+		t.Fatalf("unexpected request %+v", edit)
+	}
+	<-reqs
+}
+
+func TestPaginatorPageBounds(t *testing.T) {
+	var offsets []int
+	source := func(offset, limit int) ([]InlineKeyboardButton, error) {
+		offsets = append(offsets, offset)
+		return numbersSource(25)(offset, limit)
+	}
+	p := NewPaginator(API{}, "num", numbersCount(25), source)
+
+	for _, data := range []string{"num:9223372036854775807", "num:-9223372036854775808", "num:2"} {
+		page, ok := p.Match(data)
+		if !ok {
+			t.Fatalf("%q not matched", data)
+		}
+		if _, err := p.Page(page); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if !reflect.DeepEqual(offsets, []int{20, 0, 20}) {
+		t.Fatalf("unexpected offsets requested to the source %v", offsets)
+	}
+
+	empty := NewPaginator(API{}, "num", numbersCount(0), func(_, _ int) ([]InlineKeyboardButton, error) {
+		t.Fatal("source called without items")
+		return nil, nil
+	})
+	if _, err := empty.Page(3); err != nil {
+		t.Fatal(err)
+	}
+}
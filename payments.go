@@ -0,0 +1,268 @@
+/*
+ * Echotron
+ * Copyright (C) 2018-2022 The Echotron Devs
+ *
+ * Echotron is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Echotron is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package echotron
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// LabeledPrice represents a portion of the price for goods or services.
+// Amount is expressed in the smallest units of the currency (integer, not float/double).
+type LabeledPrice struct {
+	Label  string `json:"label"`
+	Amount int    `json:"amount"`
+}
+
+// Invoice contains basic information about an invoice.
+type Invoice struct {
+	Title          string `json:"title"`
+	Description    string `json:"description"`
+	StartParameter string `json:"start_parameter"`
+	Currency       string `json:"currency"`
+	TotalAmount    int    `json:"total_amount"`
+}
+
+// ShippingAddress represents a shipping address.
+type ShippingAddress struct {
+	CountryCode string `json:"country_code"`
+	State       string `json:"state"`
+	City        string `json:"city"`
+	StreetLine1 string `json:"street_line1"`
+	StreetLine2 string `json:"street_line2"`
+	PostCode    string `json:"post_code"`
+}
+
+// OrderInfo represents information about an order.
+type OrderInfo struct {
+	Name            string           `json:"name,omitempty"`
+	PhoneNumber     string           `json:"phone_number,omitempty"`
+	Email           string           `json:"email,omitempty"`
+	ShippingAddress *ShippingAddress `json:"shipping_address,omitempty"`
+}
+
+// ShippingOption represents one shipping option.
+type ShippingOption struct {
+	ID     string         `json:"id"`
+	Title  string         `json:"title"`
+	Prices []LabeledPrice `json:"prices"`
+}
+
+// SuccessfulPayment contains basic information about a successful payment.
+type SuccessfulPayment struct {
+	Currency                string     `json:"currency"`
+	TotalAmount             int        `json:"total_amount"`
+	InvoicePayload          string     `json:"invoice_payload"`
+	ShippingOptionID        string     `json:"shipping_option_id,omitempty"`
+	OrderInfo               *OrderInfo `json:"order_info,omitempty"`
+	TelegramPaymentChargeID string     `json:"telegram_payment_charge_id"`
+	ProviderPaymentChargeID string     `json:"provider_payment_charge_id"`
+}
+
+// ShippingQuery contains information about an incoming shipping query.
+type ShippingQuery struct {
+	ID              string           `json:"id"`
+	From            *User            `json:"from"`
+	InvoicePayload  string           `json:"invoice_payload"`
+	ShippingAddress *ShippingAddress `json:"shipping_address"`
+}
+
+// PreCheckoutQuery contains information about an incoming pre-checkout query.
+type PreCheckoutQuery struct {
+	ID               string     `json:"id"`
+	From             *User      `json:"from"`
+	Currency         string     `json:"currency"`
+	TotalAmount      int        `json:"total_amount"`
+	InvoicePayload   string     `json:"invoice_payload"`
+	ShippingOptionID string     `json:"shipping_option_id,omitempty"`
+	OrderInfo        *OrderInfo `json:"order_info,omitempty"`
+}
+
+// InvoiceOptions contains the optional parameters used by the SendInvoice method.
+type InvoiceOptions struct {
+	MessageThreadID           int                  `query:"message_thread_id"`
+	MaxTipAmount              int                  `query:"max_tip_amount"`
+	SuggestedTipAmounts       []int                `query:"suggested_tip_amounts"`
+	StartParameter            string               `query:"start_parameter"`
+	ProviderData              string               `query:"provider_data"`
+	PhotoURL                  string               `query:"photo_url"`
+	PhotoSize                 int                  `query:"photo_size"`
+	PhotoWidth                int                  `query:"photo_width"`
+	PhotoHeight               int                  `query:"photo_height"`
+	NeedName                  bool                 `query:"need_name"`
+	NeedPhoneNumber           bool                 `query:"need_phone_number"`
+	NeedEmail                 bool                 `query:"need_email"`
+	NeedShippingAddress       bool                 `query:"need_shipping_address"`
+	SendPhoneNumberToProvider bool                 `query:"send_phone_number_to_provider"`
+	SendEmailToProvider       bool                 `query:"send_email_to_provider"`
+	IsFlexible                bool                 `query:"is_flexible"`
+	DisableNotification       bool                 `query:"disable_notification"`
+	ProtectContent            bool                 `query:"protect_content"`
+	ReplyToMessageID          int                  `query:"reply_to_message_id"`
+	AllowSendingWithoutReply  bool                 `query:"allow_sending_without_reply"`
+	ReplyParameters           ReplyParameters      `query:"reply_parameters"`
+	ReplyMarkup               InlineKeyboardMarkup `query:"reply_markup"`
+}
+
+// InvoiceLinkOptions contains the optional parameters used by the CreateInvoiceLink method.
+type InvoiceLinkOptions struct {
+	MaxTipAmount              int    `query:"max_tip_amount"`
+	SuggestedTipAmounts       []int  `query:"suggested_tip_amounts"`
+	ProviderData              string `query:"provider_data"`
+	PhotoURL                  string `query:"photo_url"`
+	PhotoSize                 int    `query:"photo_size"`
+	PhotoWidth                int    `query:"photo_width"`
+	PhotoHeight               int    `query:"photo_height"`
+	NeedName                  bool   `query:"need_name"`
+	NeedPhoneNumber           bool   `query:"need_phone_number"`
+	NeedEmail                 bool   `query:"need_email"`
+	NeedShippingAddress       bool   `query:"need_shipping_address"`
+	SendPhoneNumberToProvider bool   `query:"send_phone_number_to_provider"`
+	SendEmailToProvider       bool   `query:"send_email_to_provider"`
+	IsFlexible                bool   `query:"is_flexible"`
+}
+
+// ShippingQueryOptions contains the optional parameters used by the AnswerShippingQuery method.
+type ShippingQueryOptions struct {
+	ShippingOptions []ShippingOption `query:"shipping_options"`
+	ErrorMessage    string           `query:"error_message"`
+}
+
+// PreCheckoutOptions contains the optional parameters used by the AnswerPreCheckoutQuery method.
+type PreCheckoutOptions struct {
+	ErrorMessage string `query:"error_message"`
+}
+
+// SendInvoice is used to send invoices.
+func (a API) SendInvoice(chatID int64, title, description, payload, providerToken, currency string, prices []LabeledPrice, opts *InvoiceOptions) (res APIResponseMessage, err error) {
+	jsn, err := json.Marshal(prices)
+	if err != nil {
+		return
+	}
+
+	var url = fmt.Sprintf(
+		"%ssendInvoice?chat_id=%d&title=%s&description=%s&payload=%s&provider_token=%s&currency=%s&prices=%s&%s",
+		a.base,
+		chatID,
+		encode(title),
+		encode(description),
+		encode(payload),
+		encode(providerToken),
+		encode(currency),
+		encode(string(jsn)),
+		querify(opts),
+	)
+
+	cnt, err := a.sendGetRequest(url)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(cnt, &res); err != nil {
+		return
+	}
+
+	err = check(res)
+	return
+}
+
+// CreateInvoiceLink is used to create a link for an invoice.
+func (a API) CreateInvoiceLink(title, description, payload, providerToken, currency string, prices []LabeledPrice, opts *InvoiceLinkOptions) (res APIResponseString, err error) {
+	jsn, err := json.Marshal(prices)
+	if err != nil {
+		return
+	}
+
+	var url = fmt.Sprintf(
+		"%screateInvoiceLink?title=%s&description=%s&payload=%s&provider_token=%s&currency=%s&prices=%s&%s",
+		a.base,
+		encode(title),
+		encode(description),
+		encode(payload),
+		encode(providerToken),
+		encode(currency),
+		encode(string(jsn)),
+		querify(opts),
+	)
+
+	cnt, err := a.sendGetRequest(url)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(cnt, &res); err != nil {
+		return
+	}
+
+	err = check(res)
+	return
+}
+
+// AnswerShippingQuery is used to reply to shipping queries.
+// If you sent an invoice requesting a shipping address and the parameter IsFlexible was specified,
+// the Bot API will send an Update with a ShippingQuery field to the bot.
+// If ok is true, the ShippingOptions field of opts is required, otherwise ErrorMessage is.
+func (a API) AnswerShippingQuery(shippingQueryID string, ok bool, opts *ShippingQueryOptions) (res APIResponseBool, err error) {
+	var url = fmt.Sprintf(
+		"%sanswerShippingQuery?shipping_query_id=%s&ok=%t&%s",
+		a.base,
+		encode(shippingQueryID),
+		ok,
+		querify(opts),
+	)
+
+	cnt, err := a.sendGetRequest(url)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(cnt, &res); err != nil {
+		return
+	}
+
+	err = check(res)
+	return
+}
+
+// AnswerPreCheckoutQuery is used to respond to pre-checkout queries.
+// Once the user has confirmed their payment and shipping details,
+// the Bot API sends the final confirmation in the form of an Update with the field PreCheckoutQuery.
+// The bot must answer within 10 seconds after the pre-checkout query was sent.
+// If ok is false, the ErrorMessage field of opts is required.
+func (a API) AnswerPreCheckoutQuery(preCheckoutQueryID string, ok bool, opts *PreCheckoutOptions) (res APIResponseBool, err error) {
+	var url = fmt.Sprintf(
+		"%sanswerPreCheckoutQuery?pre_checkout_query_id=%s&ok=%t&%s",
+		a.base,
+		encode(preCheckoutQueryID),
+		ok,
+		querify(opts),
+	)
+
+	cnt, err := a.sendGetRequest(url)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(cnt, &res); err != nil {
+		return
+	}
+
+	err = check(res)
+	return
+}
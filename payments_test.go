@@ -0,0 +1,117 @@
+package echotron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSendInvoice(t *testing.T) {
+	a, reqs := newMockAPI(t, `{"message_id":1,"invoice":{"title":"Echotron","currency":"EUR","total_amount":500}}`)
+
+	res, err := a.SendInvoice(
+		42,
+		"Echotron",
+		"Support the development",
+		"payload",
+		"provider",
+		"EUR",
+		[]LabeledPrice{{Label: "Donation", Amount: 500}},
+		&InvoiceOptions{NeedEmail: true, SuggestedTipAmounts: []int{100, 200}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.Result.Invoice == nil || res.Result.Invoice.TotalAmount != 500 {
+		t.Fatalf("unexpected invoice %+v", res.Result.Invoice)
+	}
+
+	r := <-reqs
+	if r.method != "sendInvoice" {
+		t.Fatalf("unexpected method %s", r.method)
+	}
+
+	for k, v := range map[string]string{
+		"chat_id":               "42",
+		"currency":              "EUR",
+		"prices":                `[{"label":"Donation","amount":500}]`,
+		"need_email":            "true",
+		"suggested_tip_amounts": "[100,200]",
+	} {
+		if got := r.query.Get(k); got != v {
+			t.Errorf("expected %s=%s, got %q", k, v, got)
+		}
+	}
+}
+
+func TestCreateInvoiceLink(t *testing.T) {
+	a, reqs := newMockAPI(t, `"https://t.me/$invoice"`)
+
+	res, err := a.CreateInvoiceLink("Echotron", "Support", "payload", "provider", "EUR", []LabeledPrice{{Label: "Donation", Amount: 500}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.Result != "https://t.me/$invoice" {
+		t.Fatalf("unexpected link %q", res.Result)
+	}
+
+	if r := <-reqs; r.method != "createInvoiceLink" || r.query.Get("payload") != "payload" {
+		t.Fatalf("unexpected request %+v", r)
+	}
+}
+
+func TestAnswerShippingQuery(t *testing.T) {
+	a, reqs := newMockAPI(t, "true")
+
+	_, err := a.AnswerShippingQuery("query", true, &ShippingQueryOptions{
+		ShippingOptions: []ShippingOption{
+			{ID: "std", Title: "Standard", Prices: []LabeledPrice{{Label: "Shipping", Amount: 300}}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := <-reqs
+	if r.query.Get("ok") != "true" || r.query.Get("shipping_query_id") != "query" {
+		t.Fatalf("unexpected request %+v", r)
+	}
+	if got := r.query.Get("shipping_options"); got != `[{"id":"std","title":"Standard","prices":[{"label":"Shipping","amount":300}]}]` {
+		t.Fatalf("unexpected shipping options %s", got)
+	}
+}
+
+func TestAnswerPreCheckoutQuery(t *testing.T) {
+	a, reqs := newMockAPI(t, "true")
+
+	if _, err := a.AnswerPreCheckoutQuery("query", false, &PreCheckoutOptions{ErrorMessage: "Out of stock"}); err != nil {
+		t.Fatal(err)
+	}
+
+	r := <-reqs
+	if r.method != "answerPreCheckoutQuery" || r.query.Get("ok") != "false" || r.query.Get("error_message") != "Out of stock" {
+		t.Fatalf("unexpected request %+v", r)
+	}
+}
+
+func TestPaymentsRouting(t *testing.T) {
+	updates := make(chan int64, 1)
+	d := NewDispatcher("token", func(chatID int64) Bot { return chatRecorder{chatID, updates} })
+
+	for _, u := range []*Update{
+		{ShippingQuery: &ShippingQuery{ID: "1", From: &User{ID: 7}}},
+		{PreCheckoutQuery: &PreCheckoutQuery{ID: "2", From: &User{ID: 7}}},
+	} {
+		d.updates <- u
+
+		select {
+		case id := <-updates:
+			if id != 7 {
+				t.Fatalf("expected chat 7, got %d", id)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("update not dispatched")
+		}
+	}
+}
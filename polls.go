@@ -0,0 +1,201 @@
+/*
+ * Echotron
+ * Copyright (C) 2018-2022 The Echotron Devs
+ *
+ * Echotron is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Echotron is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package echotron
+
+import "sync"
+
+// PollResults contains the aggregated answers to a poll tracked by a PollTracker.
+type PollResults struct {
+	Poll      Poll
+	ChatID    int64
+	MessageID int
+	// Answers contains the options chosen by each user that voted in a non-anonymous poll.
+	Answers map[int64][]int
+	// Counts contains the number of votes received by each option, including the anonymous ones,
+	// as reported by the last Poll update.
+	Counts []int
+	// AnswerCounts contains the number of votes received by each option from the users in Answers.
+	AnswerCounts []int
+}
+
+// Correct returns the IDs of the users that chose the correct option of a quiz.
+func (p PollResults) Correct() (ret []int64) {
+	if p.Poll.Type != string(Quiz) {
+		return
+	}
+
+	for userID, options := range p.Answers {
+		if len(options) == 1 && options[0] == p.Poll.CorrectOptionID {
+			ret = append(ret, userID)
+		}
+	}
+
+	return
+}
+
+// PollTracker keeps track of the polls sent by the bot and aggregates their answers
+// received through the Poll and PollAnswer updates.
+type PollTracker struct {
+	polls      map[string]*PollResults
+	dispatcher *Dispatcher
+	mu         sync.Mutex
+}
+
+// NewPollTracker returns a new instance of the PollTracker object.
+func NewPollTracker() *PollTracker {
+	return &PollTracker{polls: make(map[string]*PollResults)}
+}
+
+// SetDispatcher links the PollTracker to the Dispatcher, so that the tracked polls are also
+// associated to their chat in the Dispatcher and the Poll and PollAnswer updates about
+// the polls sent with SendPoll are passed to the Bot instance of that chat.
+func (t *PollTracker) SetDispatcher(d *Dispatcher) {
+	t.mu.Lock()
+	t.dispatcher = d
+	t.mu.Unlock()
+}
+
+// SendPoll is a wrapper for API.SendPoll which starts tracking the poll once it's sent.
+func (t *PollTracker) SendPoll(a API, chatID int64, question string, options []string, opts *PollOptions) (res APIResponseMessage, err error) {
+	if res, err = a.SendPoll(chatID, question, options, opts); err == nil {
+		t.Track(res.Result)
+	}
+	return
+}
+
+// Track starts tracking the poll contained in the given message, usually the one returned by SendPoll.
+// Messages without a poll are ignored.
+func (t *PollTracker) Track(msg *Message) {
+	if msg == nil || msg.Poll == nil {
+		return
+	}
+
+	res := &PollResults{
+		Poll:         *msg.Poll,
+		MessageID:    msg.ID,
+		Answers:      make(map[int64][]int),
+		Counts:       make([]int, len(msg.Poll.Options)),
+		AnswerCounts: make([]int, len(msg.Poll.Options)),
+	}
+	for i, o := range msg.Poll.Options {
+		if o != nil {
+			res.Counts[i] = o.VoterCount
+		}
+	}
+
+	if msg.Chat != nil {
+		res.ChatID = msg.Chat.ID
+	}
+
+	t.mu.Lock()
+	t.polls[msg.Poll.ID] = res
+	d := t.dispatcher
+	t.mu.Unlock()
+
+	if d != nil && msg.Chat != nil {
+		d.AddPoll(msg.Poll.ID, msg.Chat.ID)
+	}
+}
+
+// Forget stops tracking the poll with the given ID.
+func (t *PollTracker) Forget(pollID string) {
+	t.mu.Lock()
+	delete(t.polls, pollID)
+	d := t.dispatcher
+	t.mu.Unlock()
+
+	if d != nil {
+		d.DelPoll(pollID)
+	}
+}
+
+// Update processes the Poll and PollAnswer updates about the tracked polls and
+// returns true if the update has been used.
+func (t *PollTracker) Update(u *Update) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	switch {
+	case u.Poll != nil:
+		res, ok := t.polls[u.Poll.ID]
+		if !ok {
+			return false
+		}
+
+		res.Poll = *u.Poll
+		// Poll updates carry the authoritative vote count, including the anonymous votes.
+		for i, o := range u.Poll.Options {
+			if i < len(res.Counts) && o != nil {
+				res.Counts[i] = o.VoterCount
+			}
+		}
+		return true
+
+	case u.PollAnswer != nil && u.PollAnswer.User != nil:
+		res, ok := t.polls[u.PollAnswer.PollID]
+		if !ok {
+			return false
+		}
+
+		userID := u.PollAnswer.User.ID
+		// Counts is left to the Poll updates, which Telegram sends for the same vote.
+		for _, o := range res.Answers[userID] {
+			if o < len(res.AnswerCounts) && res.AnswerCounts[o] > 0 {
+				res.AnswerCounts[o]--
+			}
+		}
+
+		// An empty list of options means that the user retracted the vote.
+		if len(u.PollAnswer.OptionIDs) == 0 {
+			delete(res.Answers, userID)
+			return true
+		}
+
+		res.Answers[userID] = append([]int(nil), u.PollAnswer.OptionIDs...)
+		for _, o := range u.PollAnswer.OptionIDs {
+			if o < len(res.AnswerCounts) {
+				res.AnswerCounts[o]++
+			}
+		}
+		return true
+	}
+
+	return false
+}
+
+// Results returns a copy of the aggregated results of the poll with the given ID.
+func (t *PollTracker) Results(pollID string) (PollResults, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	res, ok := t.polls[pollID]
+	if !ok {
+		return PollResults{}, false
+	}
+
+	cp := *res
+	cp.Counts = append([]int(nil), res.Counts...)
+	cp.AnswerCounts = append([]int(nil), res.AnswerCounts...)
+	cp.Answers = make(map[int64][]int, len(res.Answers))
+	for k, v := range res.Answers {
+		cp.Answers[k] = append([]int(nil), v...)
+	}
+
+	return cp, true
+}
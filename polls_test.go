@@ -0,0 +1,98 @@
+package echotron
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPollTracker(t *testing.T) {
+	tracker := NewPollTracker()
+	tracker.Track(&Message{
+		ID:   10,
+		Chat: &Chat{ID: 1},
+		Poll: &Poll{
+			ID:              "poll",
+			Type:            string(Quiz),
+			Options:         []*PollOption{{Text: "a"}, {Text: "b"}},
+			CorrectOptionID: 1,
+		},
+	})
+
+	answer := func(userID int64, options ...int) bool {
+		return tracker.Update(&Update{PollAnswer: &PollAnswer{PollID: "poll", User: &User{ID: userID}, OptionIDs: options}})
+	}
+
+	if !answer(100, 1) || !answer(200, 0) || !answer(300, 0) {
+		t.Fatal("answers not tracked")
+	}
+	// User 300 changes their mind.
+	answer(300)
+	answer(300, 1)
+
+	if tracker.Update(&Update{PollAnswer: &PollAnswer{PollID: "unknown", User: &User{ID: 1}}}) {
+		t.Fatal("answer to unknown poll tracked")
+	}
+
+	res, ok := tracker.Results("poll")
+	if !ok {
+		t.Fatal("poll not found")
+	}
+
+	if !reflect.DeepEqual(res.AnswerCounts, []int{1, 2}) {
+		t.Fatalf("unexpected answer counts %v", res.AnswerCounts)
+	}
+
+	if correct := res.Correct(); len(correct) != 2 {
+		t.Fatalf("unexpected correct answers %v", correct)
+	}
+
+	tracker.Update(&Update{Poll: &Poll{ID: "poll", Options: []*PollOption{{VoterCount: 3}, {VoterCount: 4}}}})
+	if res, _ = tracker.Results("poll"); !reflect.DeepEqual(res.Counts, []int{3, 4}) {
+		t.Fatalf("unexpected counts after poll update %v", res.Counts)
+	}
+}
+
+func TestPollTrackerVoteOrder(t *testing.T) {
+	var (
+		poll   = &Update{Poll: &Poll{ID: "poll", Options: []*PollOption{{VoterCount: 1}, {VoterCount: 0}}}}
+		answer = &Update{PollAnswer: &PollAnswer{PollID: "poll", User: &User{ID: 100}, OptionIDs: []int{0}}}
+	)
+
+	for _, order := range [][]*Update{{poll, answer}, {answer, poll}} {
+		tracker := NewPollTracker()
+		tracker.Track(&Message{ID: 10, Chat: &Chat{ID: 1}, Poll: &Poll{ID: "poll", Options: []*PollOption{{Text: "a"}, {Text: "b"}}}})
+
+		for _, u := range order {
+			if !tracker.Update(u) {
+				t.Fatal("update not tracked")
+			}
+		}
+
+		res, _ := tracker.Results("poll")
+		if !reflect.DeepEqual(res.Counts, []int{1, 0}) || !reflect.DeepEqual(res.AnswerCounts, []int{1, 0}) {
+			t.Fatalf("unexpected counts %v and answer counts %v", res.Counts, res.AnswerCounts)
+		}
+	}
+}
+
+func TestPollTrackerDispatcher(t *testing.T) {
+	var (
+		a, _    = newMockAPI(t, `{"message_id":10,"date":0,"chat":{"id":-100,"type":"group"},"poll":{"id":"sent","question":"?","options":[{"text":"a"},{"text":"b"}]}}`)
+		d       = NewDispatcher("token", func(_ int64) Bot { return nil })
+		tracker = NewPollTracker()
+	)
+
+	tracker.SetDispatcher(d)
+	if _, err := tracker.SendPoll(a, -100, "?", []string{"a", "b"}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if chatID, ok := d.pollChat("sent"); !ok || chatID != -100 {
+		t.Fatalf("sent poll not associated to its chat: %d, %t", chatID, ok)
+	}
+
+	tracker.Forget("sent")
+	if _, ok := d.pollChat("sent"); ok {
+		t.Fatal("forgotten poll still associated to its chat")
+	}
+}
@@ -0,0 +1,125 @@
+/*
+ * Echotron
+ * Copyright (C) 2018-2022 The Echotron Devs
+ *
+ * Echotron is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Echotron is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package echotron
+
+import "sort"
+
+// BotProfile contains the name, the descriptions and the commands of the bot in a given language.
+type BotProfile struct {
+	Name             string
+	Description      string
+	ShortDescription string
+	Commands         []BotCommand
+	// CommandScope is the scope of Commands, the default scope if left empty.
+	CommandScope BotCommandScope
+}
+
+// SyncProfiles applies the given profiles of the bot, keyed by language code, where the empty code
+// is the default profile for the users whose language has no dedicated one.
+// Each value is compared to the current one first and set only if it differs,
+// so that running it at every startup of the bot costs only the getter calls.
+// It returns the number of values that have been changed.
+func (a API) SyncProfiles(profiles map[string]BotProfile) (changed int, err error) {
+	var langs []string
+	for lang := range profiles {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+
+	for _, lang := range langs {
+		var n int
+
+		if n, err = a.syncProfile(lang, profiles[lang]); err != nil {
+			return
+		}
+		changed += n
+	}
+
+	return
+}
+
+// syncProfile applies the profile of the bot in the given language, setting only the values that differ from the current ones.
+func (a API) syncProfile(lang string, p BotProfile) (changed int, err error) {
+	name, err := a.GetMyName(lang)
+	if err != nil {
+		return
+	}
+	if name.Result == nil || name.Result.Name != p.Name {
+		if _, err = a.SetMyName(p.Name, lang); err != nil {
+			return
+		}
+		changed++
+	}
+
+	desc, err := a.GetMyDescription(lang)
+	if err != nil {
+		return
+	}
+	if desc.Result == nil || desc.Result.Description != p.Description {
+		if _, err = a.SetMyDescription(p.Description, lang); err != nil {
+			return
+		}
+		changed++
+	}
+
+	short, err := a.GetMyShortDescription(lang)
+	if err != nil {
+		return
+	}
+	if short.Result == nil || short.Result.ShortDescription != p.ShortDescription {
+		if _, err = a.SetMyShortDescription(p.ShortDescription, lang); err != nil {
+			return
+		}
+		changed++
+	}
+
+	var opts = &CommandOptions{Scope: p.CommandScope, LanguageCode: lang}
+
+	cmds, err := a.GetMyCommands(opts)
+	if err != nil {
+		return
+	}
+	if !equalCommands(cmds.Result, p.Commands) {
+		if len(p.Commands) == 0 {
+			_, err = a.DeleteMyCommands(opts)
+		} else {
+			_, err = a.SetMyCommands(opts, p.Commands...)
+		}
+		if err != nil {
+			return
+		}
+		changed++
+	}
+
+	return
+}
+
+// equalCommands returns true if the current commands are the same as the wanted ones, in the same order.
+func equalCommands(current []*BotCommand, wanted []BotCommand) bool {
+	if len(current) != len(wanted) {
+		return false
+	}
+
+	for i, c := range current {
+		if c == nil || *c != wanted[i] {
+			return false
+		}
+	}
+	return true
+}
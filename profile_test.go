@@ -0,0 +1,77 @@
+package echotron
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"reflect"
+	"testing"
+)
+
+func TestSyncProfiles(t *testing.T) {
+	var (
+		calls   []string
+		results = map[string]string{
+			"getMyName":             `{"name":"Echotron"}`,
+			"getMyDescription":      `{"description":"An old description"}`,
+			"getMyShortDescription": `{"short_description":"Echo"}`,
+			"getMyCommands":         `[{"command":"start","description":"Start the bot"}]`,
+		}
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method := path.Base(r.URL.Path)
+		lang := r.URL.Query().Get("language_code")
+		calls = append(calls, method+"/"+lang)
+
+		res, ok := results[method]
+		if !ok {
+			res = "true"
+		}
+		w.Write([]byte(`{"ok":true,"result":` + res + `}`))
+	}))
+	defer srv.Close()
+
+	a := API{token: "token", base: srv.URL + "/bottoken/"}
+
+	changed, err := a.SyncProfiles(map[string]BotProfile{
+		"": {
+			Name:             "Echotron",
+			Description:      "A new description",
+			ShortDescription: "Echo",
+			Commands:         []BotCommand{{Command: "start", Description: "Start the bot"}},
+		},
+		"it": {
+			Name:             "Echotron",
+			Description:      "An old description",
+			ShortDescription: "Echo",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if changed != 2 {
+		t.Fatalf("expected 2 changes, got %d", changed)
+	}
+
+	expected := []string{
+		"getMyName/", "getMyDescription/", "setMyDescription/", "getMyShortDescription/", "getMyCommands/",
+		"getMyName/it", "getMyDescription/it", "getMyShortDescription/it", "getMyCommands/it", "deleteMyCommands/it",
+	}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Fatalf("unexpected calls %v", calls)
+	}
+}
+
+func TestSetMyName(t *testing.T) {
+	a, reqs := newMockAPI(t, "true")
+
+	if _, err := a.SetMyName("Echotron", "it"); err != nil {
+		t.Fatal(err)
+	}
+
+	if req := <-reqs; req.method != "setMyName" || req.query.Get("name") != "Echotron" || req.query.Get("language_code") != "it" {
+		t.Fatalf("unexpected request %s %v", req.method, req.query)
+	}
+}
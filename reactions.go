@@ -0,0 +1,189 @@
+/*
+ * Echotron
+ * Copyright (C) 2018-2022 The Echotron Devs
+ *
+ * Echotron is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Echotron is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package echotron
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// ReactionTypeType is a custom type for the various reaction types.
+type ReactionTypeType string
+
+// These are all the possible reaction types.
+const (
+	EmojiReactionType       ReactionTypeType = "emoji"
+	CustomEmojiReactionType                  = "custom_emoji"
+)
+
+// ReactionType describes the type of a reaction, which is either a standard emoji or a custom emoji.
+type ReactionType struct {
+	Type          ReactionTypeType `json:"type"`
+	Emoji         string           `json:"emoji,omitempty"`
+	CustomEmojiID string           `json:"custom_emoji_id,omitempty"`
+}
+
+// EmojiReaction returns a ReactionType with the given standard emoji.
+func EmojiReaction(emoji string) ReactionType {
+	return ReactionType{Type: EmojiReactionType, Emoji: emoji}
+}
+
+// CustomEmojiReaction returns a ReactionType with the custom emoji with the given ID.
+func CustomEmojiReaction(customEmojiID string) ReactionType {
+	return ReactionType{Type: CustomEmojiReactionType, CustomEmojiID: customEmojiID}
+}
+
+// ReactionCount represents a reaction added to a message along with the number of times it was added.
+type ReactionCount struct {
+	Type       ReactionType `json:"type"`
+	TotalCount int          `json:"total_count"`
+}
+
+// MessageReactionUpdated represents a change of a reaction on a message performed by a user.
+type MessageReactionUpdated struct {
+	Chat        *Chat          `json:"chat"`
+	MessageID   int            `json:"message_id"`
+	User        *User          `json:"user,omitempty"`
+	ActorChat   *Chat          `json:"actor_chat,omitempty"`
+	Date        int            `json:"date"`
+	OldReaction []ReactionType `json:"old_reaction"`
+	NewReaction []ReactionType `json:"new_reaction"`
+}
+
+// MessageReactionCountUpdated represents reaction changes on a message with anonymous reactions.
+type MessageReactionCountUpdated struct {
+	Chat      *Chat           `json:"chat"`
+	MessageID int             `json:"message_id"`
+	Date      int             `json:"date"`
+	Reactions []ReactionCount `json:"reactions"`
+}
+
+// MessageReactionOptions contains the optional parameters used by the SetMessageReaction method.
+type MessageReactionOptions struct {
+	Reaction []ReactionType `query:"reaction"`
+	IsBig    bool           `query:"is_big"`
+}
+
+// SetMessageReaction is used to change the chosen reactions on a message.
+// Leaving the Reaction field of opts empty removes the reactions of the bot from the message.
+func (a API) SetMessageReaction(chatID int64, messageID int, opts *MessageReactionOptions) (res APIResponseBool, err error) {
+	var url = fmt.Sprintf(
+		"%ssetMessageReaction?chat_id=%d&message_id=%d&%s",
+		a.base,
+		chatID,
+		messageID,
+		querify(opts),
+	)
+
+	cnt, err := a.sendGetRequest(url)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(cnt, &res); err != nil {
+		return
+	}
+
+	err = check(res)
+	return
+}
+
+// reactionKey identifies a message whose reactions are counted by a ReactionTally.
+type reactionKey struct {
+	chatID    int64
+	messageID int
+}
+
+// ReactionTally counts the reactions of the messages in memory, by processing
+// the MessageReaction and MessageReactionCount updates.
+type ReactionTally struct {
+	counts map[reactionKey]map[ReactionType]int
+	mu     sync.Mutex
+}
+
+// NewReactionTally returns a new instance of the ReactionTally object.
+func NewReactionTally() *ReactionTally {
+	return &ReactionTally{counts: make(map[reactionKey]map[ReactionType]int)}
+}
+
+// Update processes the MessageReaction and MessageReactionCount updates and
+// returns true if the update has been used.
+func (t *ReactionTally) Update(u *Update) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	switch {
+	case u.MessageReaction != nil && u.MessageReaction.Chat != nil:
+		r := u.MessageReaction
+		key := reactionKey{r.Chat.ID, r.MessageID}
+
+		counts, ok := t.counts[key]
+		if !ok {
+			counts = make(map[ReactionType]int)
+			t.counts[key] = counts
+		}
+
+		for _, rt := range r.OldReaction {
+			if counts[rt] > 1 {
+				counts[rt]--
+			} else {
+				delete(counts, rt)
+			}
+		}
+		for _, rt := range r.NewReaction {
+			counts[rt]++
+		}
+		return true
+
+	case u.MessageReactionCount != nil && u.MessageReactionCount.Chat != nil:
+		r := u.MessageReactionCount
+		// Count updates carry the authoritative totals, including the anonymous reactions.
+		counts := make(map[ReactionType]int, len(r.Reactions))
+		for _, rc := range r.Reactions {
+			if rc.TotalCount > 0 {
+				counts[rc.Type] = rc.TotalCount
+			}
+		}
+		t.counts[reactionKey{r.Chat.ID, r.MessageID}] = counts
+		return true
+	}
+
+	return false
+}
+
+// Counts returns a copy of the number of times each reaction has been added to the given message.
+func (t *ReactionTally) Counts(chatID int64, messageID int) map[ReactionType]int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	counts := t.counts[reactionKey{chatID, messageID}]
+	cp := make(map[ReactionType]int, len(counts))
+	for k, v := range counts {
+		cp[k] = v
+	}
+	return cp
+}
+
+// Forget stops counting the reactions of the given message.
+func (t *ReactionTally) Forget(chatID int64, messageID int) {
+	t.mu.Lock()
+	delete(t.counts, reactionKey{chatID, messageID})
+	t.mu.Unlock()
+}
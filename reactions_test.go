@@ -0,0 +1,101 @@
+package echotron
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSetMessageReaction(t *testing.T) {
+	a, reqs := newMockAPI(t, "true")
+
+	_, err := a.SetMessageReaction(-100, 10, &MessageReactionOptions{
+		Reaction: []ReactionType{EmojiReaction("👍"), CustomEmojiReaction("5368324170671202286")},
+		IsBig:    true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := <-reqs
+	if req.method != "setMessageReaction" || req.query.Get("message_id") != "10" || req.query.Get("is_big") != "true" {
+		t.Fatalf("unexpected request %s %v", req.method, req.query)
+	}
+
+	var reaction []ReactionType
+	if err = json.Unmarshal([]byte(req.query.Get("reaction")), &reaction); err != nil {
+		t.Fatal(err)
+	}
+	if len(reaction) != 2 || reaction[0].Type != EmojiReactionType || reaction[1].CustomEmojiID != "5368324170671202286" {
+		t.Fatalf("unexpected reaction %+v", reaction)
+	}
+}
+
+func TestReactionTally(t *testing.T) {
+	var (
+		tally  = NewReactionTally()
+		chat   = &Chat{ID: -100}
+		thumbs = EmojiReaction("👍")
+		fire   = EmojiReaction("🔥")
+	)
+
+	react := func(userID int64, old, new []ReactionType) bool {
+		return tally.Update(&Update{MessageReaction: &MessageReactionUpdated{
+			Chat:        chat,
+			MessageID:   10,
+			User:        &User{ID: userID},
+			OldReaction: old,
+			NewReaction: new,
+		}})
+	}
+
+	if !react(1, nil, []ReactionType{thumbs}) || !react(2, nil, []ReactionType{thumbs, fire}) {
+		t.Fatal("reactions not counted")
+	}
+	// User 2 removes the thumbs up.
+	react(2, []ReactionType{thumbs, fire}, []ReactionType{fire})
+
+	if counts := tally.Counts(-100, 10); !reflect.DeepEqual(counts, map[ReactionType]int{thumbs: 1, fire: 1}) {
+		t.Fatalf("unexpected counts %v", counts)
+	}
+
+	tally.Update(&Update{MessageReactionCount: &MessageReactionCountUpdated{
+		Chat:      chat,
+		MessageID: 10,
+		Reactions: []ReactionCount{{Type: thumbs, TotalCount: 5}},
+	}})
+	if counts := tally.Counts(-100, 10); !reflect.DeepEqual(counts, map[ReactionType]int{thumbs: 5}) {
+		t.Fatalf("unexpected counts %v", counts)
+	}
+
+	if tally.Update(&Update{Message: &Message{}}) {
+		t.Fatal("unrelated update used")
+	}
+
+	tally.Forget(-100, 10)
+	if counts := tally.Counts(-100, 10); len(counts) != 0 {
+		t.Fatalf("unexpected counts %v", counts)
+	}
+}
+
+func TestReactionRouting(t *testing.T) {
+	updates := make(chan int64, 1)
+	d := NewDispatcher("token", func(chatID int64) Bot { return chatRecorder{chatID, updates} })
+
+	expect := func(chatID int64) {
+		select {
+		case id := <-updates:
+			if id != chatID {
+				t.Fatalf("expected chat %d, got %d", chatID, id)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("update for chat %d not dispatched", chatID)
+		}
+	}
+
+	d.updates <- &Update{MessageReaction: &MessageReactionUpdated{Chat: &Chat{ID: -100}, User: &User{ID: 1}}}
+	expect(-100)
+	d.updates <- &Update{MessageReactionCount: &MessageReactionCountUpdated{Chat: &Chat{ID: -200}}}
+	expect(-200)
+}
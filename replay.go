@@ -0,0 +1,205 @@
+/*
+ * Echotron
+ * Copyright (C) 2018-2022 The Echotron Devs
+ *
+ * Echotron is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Echotron is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package echotron
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// RecordedUpdate represents a single line of an update recording in the JSON Lines format.
+type RecordedUpdate struct {
+	Time   time.Time       `json:"time"`
+	Update json.RawMessage `json:"update"`
+}
+
+// recorder writes the raw updates received by the Dispatcher to an io.Writer.
+type recorder struct {
+	w  io.Writer
+	mu sync.Mutex
+}
+
+// write appends the given raw update to the recording as a new line.
+func (r *recorder) write(raw []byte) error {
+	jsn, err := json.Marshal(RecordedUpdate{Time: time.Now(), Update: raw})
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, err = r.w.Write(append(jsn, '\n'))
+	return err
+}
+
+// close closes the underlying io.Writer if it implements io.Closer.
+func (r *recorder) close() error {
+	if c, ok := r.w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// Record makes the Dispatcher write every incoming update to w in the JSON Lines format,
+// one RecordedUpdate per line, so that it can later be fed back with Replay.
+// Any previous recording is stopped.
+func (d *Dispatcher) Record(w io.Writer) error {
+	if err := d.StopRecording(); err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	d.recorder = &recorder{w: w}
+	d.mu.Unlock()
+	return nil
+}
+
+// RecordFile is a wrapper function for Record which appends the recording to the file at the given path,
+// creating it if it doesn't exist.
+func (d *Dispatcher) RecordFile(path string) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	return d.Record(f)
+}
+
+// StopRecording stops the current recording, if any, and closes its writer if it implements io.Closer.
+func (d *Dispatcher) StopRecording() error {
+	d.mu.Lock()
+	r := d.recorder
+	d.recorder = nil
+	d.mu.Unlock()
+
+	if r == nil {
+		return nil
+	}
+	return r.close()
+}
+
+// record writes the raw update to the current recording, if any.
+func (d *Dispatcher) record(raw []byte) {
+	d.mu.Lock()
+	r := d.recorder
+	d.mu.Unlock()
+
+	if r == nil {
+		return
+	}
+
+	if err := r.write(raw); err != nil {
+		d.log().Error("could not record update", "error", err)
+	}
+}
+
+// apiResponseRawUpdates is the response of getUpdates with the updates left undecoded,
+// so that the Dispatcher can record them exactly as they were sent by Telegram.
+type apiResponseRawUpdates struct {
+	Result []json.RawMessage `json:"result,omitempty"`
+	APIResponseBase
+}
+
+// Returns the contained object of type APIResponseBase.
+func (a apiResponseRawUpdates) Base() APIResponseBase {
+	return a.APIResponseBase
+}
+
+// getRawUpdates is the same as GetUpdates, but returns the raw JSON of each update.
+func (a API) getRawUpdates(opts *UpdateOptions) (res apiResponseRawUpdates, err error) {
+	var url = fmt.Sprintf(
+		"%sgetUpdates?%s",
+		a.base,
+		querify(opts),
+	)
+
+	cnt, err := a.sendGetRequest(url)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(cnt, &res); err != nil {
+		return
+	}
+
+	err = check(res)
+	return
+}
+
+// Replay reads a recording produced by Record and passes each update through the
+// normal routing of the Dispatcher, so that the Update function of each Bot is called
+// exactly as it was when the updates were received.
+// The speed parameter scales the original delay between updates: 1 keeps the original timing,
+// 2 replays twice as fast and so on, while any value lesser or equal to 0 replays the updates
+// without waiting at all.
+// Replayed updates are not recorded again.
+func (d *Dispatcher) Replay(r io.Reader, speed float64) error {
+	var (
+		last    time.Time
+		scanner = bufio.NewScanner(r)
+	)
+
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	for scanner.Scan() {
+		var (
+			rec    RecordedUpdate
+			update Update
+		)
+
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		if err := json.Unmarshal(line, &rec); err != nil {
+			return err
+		}
+
+		if err := json.Unmarshal(rec.Update, &update); err != nil {
+			return err
+		}
+
+		if speed > 0 && !last.IsZero() {
+			if delay := rec.Time.Sub(last); delay > 0 {
+				time.Sleep(time.Duration(float64(delay) / speed))
+			}
+		}
+		last = rec.Time
+
+		d.enqueue(&update)
+	}
+
+	return scanner.Err()
+}
+
+// ReplayFile is a wrapper function for Replay which reads the recording from the file at the given path.
+func (d *Dispatcher) ReplayFile(path string, speed float64) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return d.Replay(f, speed)
+}
@@ -0,0 +1,134 @@
+package echotron
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type replayBot struct {
+	updates chan *Update
+}
+
+func (r replayBot) Update(u *Update) {
+	r.updates <- u
+}
+
+func TestRecordReplay(t *testing.T) {
+	var (
+		buf     bytes.Buffer
+		updates = make(chan *Update, 2)
+		d       = NewDispatcher("token", func(_ int64) Bot { return replayBot{updates} })
+	)
+
+	if err := d.Record(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	body := `{"update_id":1,"message":{"message_id":1,"date":0,"chat":{"id":42,"type":"private"},"text":"hello"}}`
+	d.HandleWebhook(httptest.NewRecorder(), httptest.NewRequest("POST", "/", strings.NewReader(body)))
+	d.record([]byte(`{"update_id":2,"message":{"message_id":2,"date":0,"chat":{"id":42,"type":"private"},"text":"world"}}`))
+	<-updates
+
+	if err := d.StopRecording(); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := strings.Count(buf.String(), "\n"); n != 2 {
+		t.Fatalf("expected 2 recorded updates, got %d", n)
+	}
+
+	go func() {
+		if err := d.Replay(&buf, 0); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	replayed := make(map[string]bool)
+	for i := 0; i < 2; i++ {
+		select {
+		case u := <-updates:
+			replayed[u.Message.Text] = true
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for replayed update")
+		}
+	}
+
+	if !replayed["hello"] || !replayed["world"] {
+		t.Fatalf("unexpected replayed updates: %v", replayed)
+	}
+}
+
+// lineWriter passes each written line to a channel, dropping the lines that do not fit in its buffer.
+type lineWriter chan []byte
+
+func (w lineWriter) Write(p []byte) (int, error) {
+	select {
+	case w <- append([]byte(nil), p...):
+	default:
+	}
+	return len(p), nil
+}
+
+func TestRecordPollingRaw(t *testing.T) {
+	var (
+		lines   = make(lineWriter, 1)
+		updates = make(chan *Update, 1)
+		update  = `{"update_id":1,"unknown_field":{"answer":42},"message":{"message_id":1,"date":0,"chat":{"id":42,"type":"private"},"text":"hello"}}`
+		sent    int32
+		d       = NewDispatcher("token", func(_ int64) Bot { return replayBot{updates} })
+	)
+
+	// The update is served only once, the following polls get no updates.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/getUpdates") && atomic.AddInt32(&sent, 1) == 1 {
+			w.Write([]byte(`{"ok":true,"result":[` + update + `]}`))
+			return
+		}
+		w.Write([]byte(`{"ok":true,"result":[]}`))
+	}))
+	defer srv.Close()
+
+	d.api = API{token: "token", base: srv.URL + "/bottoken/"}
+	d.SetLogger(nil)
+	if err := d.Record(lines); err != nil {
+		t.Fatal(err)
+	}
+	go d.PollOptions(false, &UpdateOptions{})
+
+	var line []byte
+	select {
+	case line = <-lines:
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the recorded update")
+	}
+	d.StopRecording()
+
+	var rec RecordedUpdate
+	if err := json.Unmarshal(line, &rec); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(rec.Update), `"unknown_field":{"answer":42}`) {
+		t.Fatalf("unknown field not recorded: %s", rec.Update)
+	}
+
+	replayed := make(chan *Update, 1)
+	r := NewDispatcher("token", func(_ int64) Bot { return replayBot{replayed} })
+	if err := r.Replay(bytes.NewReader(line), 0); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case u := <-replayed:
+		if u.ID != 1 || u.Message.Text != "hello" {
+			t.Fatalf("unexpected replayed update %+v", u)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the replayed update")
+	}
+}
@@ -0,0 +1,165 @@
+/*
+ * Echotron
+ * Copyright (C) 2018-2022 The Echotron Devs
+ *
+ * Echotron is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Echotron is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package echotron
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+)
+
+// ErrRequestIDInUse is returned by ShareRequestRouter when a request ID is already associated with a handler.
+var ErrRequestIDInUse = errors.New("request ID already in use")
+
+// UsersSharedHandler is the function called by a ShareRequestRouter when the users
+// requested by the corresponding button are shared with the bot.
+type UsersSharedHandler func(msg *Message, shared *UsersShared) error
+
+// ChatSharedHandler is the function called by a ShareRequestRouter when the chat
+// requested by the corresponding button is shared with the bot.
+type ChatSharedHandler func(msg *Message, shared *ChatShared) error
+
+// ShareRequestRouter creates the RequestUsersButton and RequestChatButton buttons and correlates the
+// request_id of the UsersShared and ChatShared service messages with the handler of the button that issued them.
+// The handlers are kept until they're removed with Forget, so the same keyboard can be used multiple times.
+// The handlers live in memory only: after a restart the keyboards sent before have to be registered again
+// with their explicit request IDs, otherwise the shares they produce are ignored.
+type ShareRequestRouter struct {
+	users map[int]UsersSharedHandler
+	chats map[int]ChatSharedHandler
+	mu    sync.Mutex
+}
+
+// NewShareRequestRouter returns a new instance of the ShareRequestRouter object.
+func NewShareRequestRouter() *ShareRequestRouter {
+	return &ShareRequestRouter{
+		users: make(map[int]UsersSharedHandler),
+		chats: make(map[int]ChatSharedHandler),
+	}
+}
+
+// inUse returns true if the request ID is associated with a handler.
+// It must be called with the mutex held.
+func (r *ShareRequestRouter) inUse(id int) bool {
+	_, isUsers := r.users[id]
+	_, isChat := r.chats[id]
+	return isUsers || isChat
+}
+
+// requestID returns id if it's not in use, or a random unused positive 32-bit request ID if id is 0.
+// Random IDs make it unlikely that the buttons sent before a restart match a handler registered after it.
+// It must be called with the mutex held.
+func (r *ShareRequestRouter) requestID(id int) (int, error) {
+	if id != 0 {
+		if r.inUse(id) {
+			return 0, fmt.Errorf("%w: %d", ErrRequestIDInUse, id)
+		}
+		return id, nil
+	}
+
+	var b [4]byte
+	for {
+		if _, err := rand.Read(b[:]); err != nil {
+			return 0, err
+		}
+
+		id = int(binary.BigEndian.Uint32(b[:]) & math.MaxInt32)
+		if id != 0 && !r.inUse(id) {
+			return id, nil
+		}
+	}
+}
+
+// UsersButton returns a RequestUsersButton whose shared users are passed to h.
+// If the RequestID of req is 0, a random unused one is assigned, otherwise ErrRequestIDInUse
+// is returned if it's already associated with a handler.
+func (r *ShareRequestRouter) UsersButton(text string, req KeyboardButtonRequestUsers, h UsersSharedHandler) (KeyboardButton, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id, err := r.requestID(req.RequestID)
+	if err != nil {
+		return KeyboardButton{}, err
+	}
+
+	req.RequestID = id
+	r.users[id] = h
+	return RequestUsersButton(text, req), nil
+}
+
+// ChatButton returns a RequestChatButton whose shared chat is passed to h.
+// If the RequestID of req is 0, a random unused one is assigned, otherwise ErrRequestIDInUse
+// is returned if it's already associated with a handler.
+func (r *ShareRequestRouter) ChatButton(text string, req KeyboardButtonRequestChat, h ChatSharedHandler) (KeyboardButton, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id, err := r.requestID(req.RequestID)
+	if err != nil {
+		return KeyboardButton{}, err
+	}
+
+	req.RequestID = id
+	r.chats[id] = h
+	return RequestChatButton(text, req), nil
+}
+
+// Forget removes the handlers associated with the given request ID.
+func (r *ShareRequestRouter) Forget(requestID int) {
+	r.mu.Lock()
+	delete(r.users, requestID)
+	delete(r.chats, requestID)
+	r.mu.Unlock()
+}
+
+// Update passes the UsersShared or ChatShared service message contained in the update, if any,
+// to the handler associated with its request ID and returns true if the update has been used.
+func (r *ShareRequestRouter) Update(u *Update) (bool, error) {
+	msg := u.Message
+	if msg == nil {
+		return false, nil
+	}
+
+	switch {
+	case msg.UsersShared != nil:
+		r.mu.Lock()
+		h, ok := r.users[msg.UsersShared.RequestID]
+		r.mu.Unlock()
+
+		if !ok {
+			return false, nil
+		}
+		return true, h(msg, msg.UsersShared)
+
+	case msg.ChatShared != nil:
+		r.mu.Lock()
+		h, ok := r.chats[msg.ChatShared.RequestID]
+		r.mu.Unlock()
+
+		if !ok {
+			return false, nil
+		}
+		return true, h(msg, msg.ChatShared)
+	}
+
+	return false, nil
+}
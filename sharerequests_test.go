@@ -0,0 +1,80 @@
+package echotron
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestShareRequestRouter(t *testing.T) {
+	var (
+		router = NewShareRequestRouter()
+		users  []int64
+		chat   int64
+	)
+
+	isBot := false
+	usersBtn, err := router.UsersButton("Pick users", KeyboardButtonRequestUsers{RequestID: 1, UserIsBot: &isBot, MaxQuantity: 3}, func(_ *Message, s *UsersShared) error {
+		for _, u := range s.Users {
+			users = append(users, u.UserID)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	chatBtn, err := router.ChatButton("Pick a group", KeyboardButtonRequestChat{
+		BotIsMember:            true,
+		BotAdministratorRights: &ChatAdministratorRights{CanDeleteMessages: true},
+	}, func(_ *Message, s *ChatShared) error {
+		chat = s.ChatID
+		return errors.New("handled")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if usersBtn.RequestUsers.RequestID == 0 || usersBtn.RequestUsers.RequestID == chatBtn.RequestChat.RequestID {
+		t.Fatalf("request IDs not unique: %d %d", usersBtn.RequestUsers.RequestID, chatBtn.RequestChat.RequestID)
+	}
+
+	if _, err := NewReplyKeyboard().Row(usersBtn, chatBtn).Build(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = router.ChatButton("Again", KeyboardButtonRequestChat{RequestID: 1}, nil); !errors.Is(err, ErrRequestIDInUse) {
+		t.Fatalf("expected ErrRequestIDInUse, got %v", err)
+	}
+
+	var upd Update
+	err = json.Unmarshal([]byte(`{"message":{"message_id":1,"users_shared":{"request_id":1,"users":[{"user_id":10},{"user_id":20}]}}}`), &upd)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ok, err := router.Update(&upd); !ok || err != nil {
+		t.Fatalf("users not routed: %v %v", ok, err)
+	}
+	if len(users) != 2 || users[1] != 20 {
+		t.Fatalf("unexpected users %v", users)
+	}
+
+	upd = Update{Message: &Message{ChatShared: &ChatShared{RequestID: chatBtn.RequestChat.RequestID, ChatID: -100}}}
+	if ok, err := router.Update(&upd); !ok || err == nil || chat != -100 {
+		t.Fatalf("chat not routed: %v %v %d", ok, err, chat)
+	}
+
+	router.Forget(chatBtn.RequestChat.RequestID)
+	if ok, _ := router.Update(&upd); ok {
+		t.Fatal("forgotten request routed")
+	}
+}
+
+func TestRequestButtonsValidate(t *testing.T) {
+	btn := RequestChatButton("Pick", KeyboardButtonRequestChat{RequestID: 1})
+	btn.RequestContact = true
+
+	if err := (ReplyKeyboardMarkup{Keyboard: [][]KeyboardButton{{btn}}}).Validate(); !errors.Is(err, ErrInvalidButton) {
+		t.Fatalf("expected ErrInvalidButton, got %v", err)
+	}
+}
@@ -0,0 +1,530 @@
+/*
+ * Echotron
+ * Copyright (C) 2018-2022 The Echotron Devs
+ *
+ * Echotron is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Echotron is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package echotron
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// MaxMessageLength is the maximum length of the text of a message.
+	MaxMessageLength = 4096
+	// MaxCaptionLength is the maximum length of the caption of a media message.
+	MaxCaptionLength = 1024
+)
+
+// tokenKind tells apart the pieces of text found by the tokenizers used to split formatted text.
+type tokenKind int
+
+const (
+	textToken tokenKind = iota
+	openToken
+	closeToken
+)
+
+// splitToken is an indivisible piece of formatted text: a character, an escape sequence or a tag.
+type splitToken struct {
+	raw   string
+	width int
+	kind  tokenKind
+	// tag identifies the formatting opened or closed by the token.
+	tag string
+	// close is the text closing the formatting opened by an openToken.
+	close string
+}
+
+// TextChunk is a part of a text split by SplitEntities together with its entities.
+type TextChunk struct {
+	Text     string
+	Entities []MessageEntity
+}
+
+// SplitText splits the text, formatted according to the given parse mode, in parts of at most limit characters,
+// e.g. MaxMessageLength or MaxCaptionLength.
+// The text is split at the last paragraph, line or word boundary of each part, in this order of preference,
+// and only in the middle of a word if there's no other choice.
+// The formatting open at the end of a part is closed and reopened at the beginning of the next one,
+// so that each part can be sent on its own with the same parse mode.
+func SplitText(text string, limit int, mode ParseMode) []string {
+	var toks []splitToken
+
+	switch mode {
+	case HTML:
+		toks = htmlTokens(text)
+	case MarkdownV2:
+		toks = markdownTokens(text, true)
+	case Markdown:
+		toks = markdownTokens(text, false)
+	default:
+		toks = plainTokens(text)
+	}
+
+	var ret []string
+	for _, c := range splitTokens(toks, limit) {
+		ret = append(ret, c.render(toks))
+	}
+	return ret
+}
+
+// SplitEntities splits the plain text in parts of at most limit characters like SplitText,
+// moving each entity to the parts it belongs to with its offset rebased on the beginning of the part.
+// Entities spanning across two parts are split as well.
+func SplitEntities(text string, entities []MessageEntity, limit int) []TextChunk {
+	var (
+		ret     []TextChunk
+		toks    = plainTokens(text)
+		offsets = make([]int, len(toks)+1)
+	)
+
+	for i, t := range toks {
+		offsets[i+1] = offsets[i] + t.width
+	}
+
+	for _, c := range splitTokens(toks, limit) {
+		var (
+			chunk = TextChunk{Text: c.render(toks)}
+			start = offsets[c.start]
+			end   = offsets[c.end]
+		)
+
+		for _, e := range entities {
+			s, f := e.Offset, e.Offset+e.Length
+			if s < start {
+				s = start
+			}
+			if f > end {
+				f = end
+			}
+
+			if s < f {
+				e.Offset = s - start
+				e.Length = f - s
+				chunk.Entities = append(chunk.Entities, e)
+			}
+		}
+
+		ret = append(ret, chunk)
+	}
+
+	return ret
+}
+
+// SendLongMessage sends the text splitting it in multiple messages of at most MaxMessageLength characters
+// with SplitText, or SplitEntities if the Entities field of opts is set, and returns all the sent messages.
+// Only the first message is a reply, according to ReplyToMessageID or ReplyParameters, and only the last one has the ReplyMarkup.
+// If sending a part fails, the messages sent so far are returned together with the error.
+func (a API) SendLongMessage(text string, chatID int64, opts *MessageOptions) ([]*Message, error) {
+	var (
+		o      MessageOptions
+		chunks []TextChunk
+		ret    []*Message
+	)
+
+	if opts != nil {
+		o = *opts
+	}
+
+	if len(o.Entities) > 0 {
+		chunks = SplitEntities(text, o.Entities, MaxMessageLength)
+	} else {
+		for _, t := range SplitText(text, MaxMessageLength, o.ParseMode) {
+			chunks = append(chunks, TextChunk{Text: t})
+		}
+	}
+
+	for i, c := range chunks {
+		var part = o
+		part.Entities = c.Entities
+
+		if i > 0 {
+			part.ReplyToMessageID = 0
+			part.ReplyParameters = ReplyParameters{}
+		}
+		if i < len(chunks)-1 {
+			part.ReplyMarkup = nil
+		}
+
+		res, err := a.SendMessage(c.Text, chatID, &part)
+		if err != nil {
+			return ret, err
+		}
+		ret = append(ret, res.Result)
+	}
+
+	return ret, nil
+}
+
+// tokenRange is a part of a list of tokens together with the formatting open at its boundaries.
+type tokenRange struct {
+	start, end int
+	open       []*splitToken
+	close      []*splitToken
+}
+
+// render returns the text of the range, reopening and closing the formatting open at its boundaries.
+func (r tokenRange) render(toks []splitToken) string {
+	var sb strings.Builder
+
+	for _, t := range r.open {
+		sb.WriteString(t.raw)
+	}
+	for _, t := range toks[r.start:r.end] {
+		sb.WriteString(t.raw)
+	}
+	for i := len(r.close) - 1; i >= 0; i-- {
+		sb.WriteString(r.close[i].close)
+	}
+
+	return sb.String()
+}
+
+// splitCandidate is a point where the text can be split.
+type splitCandidate struct {
+	index int
+	skip  int
+	width int
+	stack []*splitToken
+}
+
+// splitTokens splits the tokens in ranges whose text is at most limit characters long.
+func splitTokens(toks []splitToken, limit int) (ret []tokenRange) {
+	var (
+		start  int
+		prefix []*splitToken
+	)
+
+	for start < len(toks) {
+		// Whitespace at the beginning of a part is dropped.
+		for start < len(toks) && isSplitSpace(toks[start]) {
+			start++
+		}
+		if start == len(toks) {
+			break
+		}
+
+		var (
+			i     = start
+			width int
+			stack = append([]*splitToken(nil), prefix...)
+			// Candidates for paragraph, line and word boundaries.
+			cands [3]*splitCandidate
+		)
+
+		for ; i < len(toks); i++ {
+			t := &toks[i]
+
+			switch t.kind {
+			case openToken:
+				stack = append(stack, t)
+				continue
+			case closeToken:
+				stack = popTag(stack, t.tag)
+				continue
+			}
+
+			if level := boundaryLevel(toks, i); level >= 0 && i > start {
+				// Paragraph breaks are made of two tokens.
+				skip := 1
+				if level == 0 {
+					skip = 2
+				}
+
+				cands[level] = &splitCandidate{
+					index: i,
+					skip:  skip,
+					width: width,
+					stack: append([]*splitToken(nil), stack...),
+				}
+			}
+
+			if width > 0 && width+t.width > limit {
+				break
+			}
+			width += t.width
+		}
+
+		if i == len(toks) {
+			if end := trimSplitSpace(toks, start, i); hasSplitText(toks, start, end) {
+				ret = append(ret, tokenRange{start: start, end: end, open: prefix, close: stack})
+			}
+			break
+		}
+
+		cut := bestCandidate(cands, limit)
+		if cut == nil {
+			cut = &splitCandidate{index: i, stack: stack}
+		}
+
+		// Parts made only of tags and whitespace would be rejected as empty messages,
+		// the formatting they contain is carried over to the next part anyway.
+		if end := trimSplitSpace(toks, start, cut.index); hasSplitText(toks, start, end) {
+			ret = append(ret, tokenRange{start: start, end: end, open: prefix, close: cut.stack})
+		}
+		start = cut.index + cut.skip
+		prefix = cut.stack
+	}
+
+	return
+}
+
+// trimSplitSpace returns the end of the range of tokens without the trailing whitespace.
+func trimSplitSpace(toks []splitToken, start, end int) int {
+	for end > start && isSplitSpace(toks[end-1]) {
+		end--
+	}
+	return end
+}
+
+// bestCandidate returns the split point at the highest level boundary that keeps
+// the part at least half of the limit long, or the last one found if there's none.
+func bestCandidate(cands [3]*splitCandidate, limit int) *splitCandidate {
+	var last *splitCandidate
+
+	for _, c := range cands {
+		if c == nil {
+			continue
+		}
+		if c.width >= limit/2 {
+			return c
+		}
+		if last == nil || c.index > last.index {
+			last = c
+		}
+	}
+
+	return last
+}
+
+// boundaryLevel returns 0 if the token at index i starts a paragraph break, 1 if it's a line break,
+// 2 if it's a space and -1 otherwise.
+func boundaryLevel(toks []splitToken, i int) int {
+	switch toks[i].raw {
+	case "\n":
+		if i+1 < len(toks) && toks[i+1].raw == "\n" {
+			return 0
+		}
+		return 1
+	case " ":
+		return 2
+	default:
+		return -1
+	}
+}
+
+// hasSplitText returns true if the range of tokens contains some text other than whitespace.
+func hasSplitText(toks []splitToken, start, end int) bool {
+	for _, t := range toks[start:end] {
+		if t.kind == textToken && !isSplitSpace(t) {
+			return true
+		}
+	}
+	return false
+}
+
+func isSplitSpace(t splitToken) bool {
+	return t.kind == textToken && (t.raw == "\n" || t.raw == " ")
+}
+
+// popTag removes the last formatting with the given tag from the stack.
+func popTag(stack []*splitToken, tag string) []*splitToken {
+	for i := len(stack) - 1; i >= 0; i-- {
+		if stack[i].tag == tag {
+			return append(stack[:i:i], stack[i+1:]...)
+		}
+	}
+	return stack
+}
+
+// runeToken returns the token made of the first character of s.
+func runeToken(s string) splitToken {
+	r, size := utf8.DecodeRuneInString(s)
+	return splitToken{raw: s[:size], width: utf16Len(string(r))}
+}
+
+// plainTokens splits text without any formatting in tokens.
+func plainTokens(text string) (toks []splitToken) {
+	for len(text) > 0 {
+		t := runeToken(text)
+		toks = append(toks, t)
+		text = text[len(t.raw):]
+	}
+	return
+}
+
+// htmlTokens splits text formatted with the HTML parse mode in tokens.
+func htmlTokens(text string) (toks []splitToken) {
+	for i := 0; i < len(text); {
+		var t splitToken
+
+		switch text[i] {
+		case '<':
+			if j := strings.IndexByte(text[i:], '>'); j != -1 {
+				raw := text[i : i+j+1]
+				if strings.HasPrefix(raw, "</") {
+					t = splitToken{raw: raw, kind: closeToken, tag: htmlTagName(raw[2 : len(raw)-1])}
+				} else {
+					name := htmlTagName(raw[1 : len(raw)-1])
+					t = splitToken{raw: raw, kind: openToken, tag: name, close: "</" + name + ">"}
+				}
+				break
+			}
+			t = runeToken(text[i:])
+
+		case '&':
+			if j := strings.IndexByte(text[i:], ';'); j > 1 && j < 10 {
+				t = splitToken{raw: text[i : i+j+1], width: 1}
+				break
+			}
+			t = runeToken(text[i:])
+
+		default:
+			t = runeToken(text[i:])
+		}
+
+		toks = append(toks, t)
+		i += len(t.raw)
+	}
+
+	return
+}
+
+// htmlTagName returns the lowercase name of the HTML tag with the given content.
+func htmlTagName(s string) string {
+	if i := strings.IndexAny(s, " \t\n"); i != -1 {
+		s = s[:i]
+	}
+	return strings.ToLower(s)
+}
+
+// markdownTokens splits text formatted with the MarkdownV2 or the legacy Markdown parse mode in tokens.
+func markdownTokens(text string, v2 bool) (toks []splitToken) {
+	var (
+		code     string
+		linkEnd  = -1
+		linkTail string
+		tags     []string
+	)
+
+	toggle := func(tag string) splitToken {
+		for i := len(tags) - 1; i >= 0; i-- {
+			if tags[i] == tag {
+				tags = append(tags[:i:i], tags[i+1:]...)
+				return splitToken{raw: tag, kind: closeToken, tag: tag}
+			}
+		}
+		tags = append(tags, tag)
+		return splitToken{raw: tag, kind: openToken, tag: tag, close: tag}
+	}
+
+	for i := 0; i < len(text); {
+		var (
+			t    splitToken
+			rest = text[i:]
+		)
+
+		switch {
+		case rest[0] == '\\' && len(rest) > 1:
+			r := runeToken(rest[1:])
+			t = splitToken{raw: rest[:1+len(r.raw)], width: r.width}
+
+		case code != "":
+			if strings.HasPrefix(rest, code) {
+				t = splitToken{raw: code, kind: closeToken, tag: code}
+				code = ""
+			} else {
+				t = runeToken(rest)
+			}
+
+		case i == linkEnd:
+			t = splitToken{raw: linkTail, kind: closeToken, tag: "["}
+			linkEnd = -1
+
+		case strings.HasPrefix(rest, "```"):
+			raw := "```"
+			if nl := strings.IndexByte(rest, '\n'); nl != -1 && !strings.ContainsAny(rest[3:nl], " `") {
+				raw = rest[:nl+1]
+			}
+			t = splitToken{raw: raw, kind: openToken, tag: "```", close: "```"}
+			code = "```"
+
+		case rest[0] == '`':
+			t = splitToken{raw: "`", kind: openToken, tag: "`", close: "`"}
+			code = "`"
+
+		case rest[0] == '[' && linkEnd == -1:
+			if end, tail := markdownLink(rest); end != -1 {
+				linkEnd, linkTail = i+end, tail
+				t = splitToken{raw: "[", kind: openToken, tag: "[", close: tail}
+			} else {
+				t = runeToken(rest)
+			}
+
+		case rest[0] == '*':
+			t = toggle("*")
+
+		case rest[0] == '_':
+			if v2 && strings.HasPrefix(rest, "__") {
+				t = toggle("__")
+			} else {
+				t = toggle("_")
+			}
+
+		case v2 && rest[0] == '~':
+			t = toggle("~")
+
+		case v2 && strings.HasPrefix(rest, "||"):
+			t = toggle("||")
+
+		default:
+			t = runeToken(rest)
+		}
+
+		toks = append(toks, t)
+		i += len(t.raw)
+	}
+
+	return
+}
+
+// markdownLink returns the position of the "](url)" closing the link starting at the beginning of s
+// together with the closing text itself, or -1 if the link isn't properly closed.
+func markdownLink(s string) (int, string) {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case ']':
+			if !strings.HasPrefix(s[i:], "](") {
+				return -1, ""
+			}
+
+			for j := i + 2; j < len(s); j++ {
+				switch s[j] {
+				case '\\':
+					j++
+				case ')':
+					return i, s[i : j+1]
+				}
+			}
+			return -1, ""
+		}
+	}
+	return -1, ""
+}
@@ -0,0 +1,116 @@
+package echotron
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitTextBoundaries(t *testing.T) {
+	text := "first paragraph\n\nsecond line\nthird line words"
+
+	for _, c := range []struct {
+		limit    int
+		expected []string
+	}{
+		{100, []string{text}},
+		{30, []string{"first paragraph", "second line\nthird line words"}},
+		{20, []string{"first paragraph", "second line", "third line words"}},
+		{10, []string{"first", "paragraph", "second", "line\nthird", "line words"}},
+		{4, []string{"firs", "t", "para", "grap", "h", "seco", "nd", "line", "thir", "d", "line", "word", "s"}},
+	} {
+		if got := SplitText(text, c.limit, ""); !reflect.DeepEqual(got, c.expected) {
+			t.Errorf("limit %d: unexpected parts %q", c.limit, got)
+		}
+	}
+}
+
+func TestSplitTextHTML(t *testing.T) {
+	text := `<b>bold <a href="https://example.com">some link</a> text</b> &amp; more`
+
+	expected := []string{
+		`<b>bold <a href="https://example.com">some</a></b>`,
+		`<b><a href="https://example.com">link</a> text</b>`,
+		`&amp; more`,
+	}
+	if got := SplitText(text, 10, HTML); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("unexpected parts %q", got)
+	}
+}
+
+func TestSplitTextTagOnLimit(t *testing.T) {
+	for text, expected := range map[string][]string{
+		"aaaa <b> </b> bbbb":       {"aaaa", "bbbb"},
+		"aaaa <i></i> <b>bbbb</b>": {"aaaa", "<b>bbbb</b>"},
+		"aaaa <b>\n\nbbbb</b>":     {"aaaa", "<b>bbbb</b>"},
+	} {
+		if got := SplitText(text, 4, HTML); !reflect.DeepEqual(got, expected) {
+			t.Errorf("%q: unexpected parts %q", text, got)
+		}
+	}
+}
+
+func TestSplitTextMarkdownV2(t *testing.T) {
+	text := "*bold \\* [a link](https://example.com/\\)) _italic_*\n```go\nx := 1\ny := 2\n```"
+
+	expected := []string{
+		"*bold \\* [a](https://example.com/\\))*",
+		"*[link](https://example.com/\\))*",
+		"*_italic_*",
+		"```go\nx := 1```",
+		"```go\ny := 2\n```",
+	}
+	if got := SplitText(text, 10, MarkdownV2); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("unexpected parts %q", got)
+	}
+}
+
+func TestSplitEntities(t *testing.T) {
+	text := "😀 hello world"
+	entities := []MessageEntity{
+		{Type: BoldEntity, Offset: 0, Length: 8},
+		{Type: ItalicEntity, Offset: 9, Length: 5},
+	}
+
+	expected := []TextChunk{
+		{Text: "😀 hello", Entities: []MessageEntity{{Type: BoldEntity, Offset: 0, Length: 8}}},
+		{Text: "world", Entities: []MessageEntity{{Type: ItalicEntity, Offset: 0, Length: 5}}},
+	}
+	if got := SplitEntities(text, entities, 10); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("unexpected parts %+v", got)
+	}
+
+	// Entities spanning across parts are split.
+	got := SplitEntities("aaaa bbbb", []MessageEntity{{Type: BoldEntity, Offset: 2, Length: 5}}, 5)
+	if len(got) != 2 || got[0].Entities[0] != (MessageEntity{Type: BoldEntity, Offset: 2, Length: 2}) || got[1].Entities[0] != (MessageEntity{Type: BoldEntity, Offset: 0, Length: 2}) {
+		t.Fatalf("unexpected parts %+v", got)
+	}
+}
+
+func TestSendLongMessage(t *testing.T) {
+	a, reqs := newMockAPI(t, `{"message_id":1}`)
+	text := strings.Repeat("word ", 1000)
+
+	msgs, err := a.SendLongMessage(text, 42, &MessageOptions{
+		ReplyToMessageID: 7,
+		ReplyMarkup:      InlineKeyboardMarkup{InlineKeyboard: [][]InlineKeyboardButton{{CallbackButton("a", "a")}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(msgs) != 2 {
+		t.Fatalf("expected 2 messages, got %d", len(msgs))
+	}
+
+	first, second := <-reqs, <-reqs
+	if utf16Len(first.query.Get("text")) > MaxMessageLength || first.query.Get("reply_to_message_id") != "7" || first.query.Get("reply_markup") != "" {
+		t.Fatalf("unexpected first request %+v", first.query)
+	}
+	if second.query.Get("reply_to_message_id") != "" || second.query.Get("reply_markup") == "" {
+		t.Fatalf("unexpected second request %+v", second.query)
+	}
+	if got := first.query.Get("text") + " " + second.query.Get("text") + " "; got != text {
+		t.Fatalf("text not preserved, %d characters instead of %d", len(got), len(text))
+	}
+}
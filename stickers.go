@@ -25,28 +25,55 @@ import (
 
 // Sticker represents a sticker.
 type Sticker struct {
-	FileID       string        `json:"file_id"`
-	FileUniqueID string        `json:"file_unique_id"`
-	Width        int           `json:"width"`
-	Height       int           `json:"height"`
-	IsAnimated   bool          `json:"is_animated"`
-	Thumb        *PhotoSize    `json:"thumb,omitempty"`
-	Emoji        string        `json:"emoji,omitempty"`
-	SetName      string        `json:"set_name,omitempty"`
-	MaskPosition *MaskPosition `json:"mask_position,omitempty"`
-	FileSize     int           `json:"file_size,omitempty"`
+	FileID           string         `json:"file_id"`
+	FileUniqueID     string         `json:"file_unique_id"`
+	Type             StickerSetType `json:"type,omitempty"`
+	Width            int            `json:"width"`
+	Height           int            `json:"height"`
+	IsAnimated       bool           `json:"is_animated"`
+	IsVideo          bool           `json:"is_video"`
+	Thumb            *PhotoSize     `json:"thumb,omitempty"`
+	Emoji            string         `json:"emoji,omitempty"`
+	SetName          string         `json:"set_name,omitempty"`
+	PremiumAnimation *File          `json:"premium_animation,omitempty"`
+	MaskPosition     *MaskPosition  `json:"mask_position,omitempty"`
+	CustomEmojiID    string         `json:"custom_emoji_id,omitempty"`
+	NeedsRepainting  bool           `json:"needs_repainting,omitempty"`
+	FileSize         int            `json:"file_size,omitempty"`
 }
 
 // StickerSet represents a sticker set.
 type StickerSet struct {
-	Name          string     `json:"name"`
-	Title         string     `json:"title"`
-	IsAnimated    bool       `json:"is_animated"`
-	ContainsMasks bool       `json:"contains_masks"`
-	Stickers      []Sticker  `json:"stickers"`
-	Thumb         *PhotoSize `json:"thumb,omitempty"`
+	Name          string         `json:"name"`
+	Title         string         `json:"title"`
+	StickerType   StickerSetType `json:"sticker_type,omitempty"`
+	IsAnimated    bool           `json:"is_animated"`
+	IsVideo       bool           `json:"is_video"`
+	ContainsMasks bool           `json:"contains_masks"`
+	Stickers      []Sticker      `json:"stickers"`
+	Thumb         *PhotoSize     `json:"thumb,omitempty"`
 }
 
+// StickerSetType is a custom type for the various types of stickers and sticker sets.
+type StickerSetType string
+
+// These are all the possible types of stickers and sticker sets.
+const (
+	RegularStickerSet     StickerSetType = "regular"
+	MaskStickerSet                       = "mask"
+	CustomEmojiStickerSet                = "custom_emoji"
+)
+
+// StickerFormat is a custom type for the various formats of the sticker files.
+type StickerFormat string
+
+// These are all the possible formats of the sticker files.
+const (
+	StaticFormat   StickerFormat = "static"
+	AnimatedFormat               = "animated"
+	VideoFormat                  = "video"
+)
+
 // MaskPosition describes the position on faces where a mask should be placed by default.
 type MaskPosition struct {
 	Point  string  `json:"point"`
@@ -55,6 +82,21 @@ type MaskPosition struct {
 	Scale  float32 `json:"scale"`
 }
 
+// InputSticker describes a sticker to be added to a sticker set.
+type InputSticker struct {
+	Sticker      InputFile     `json:"-"`
+	Format       StickerFormat `json:"format"`
+	EmojiList    []string      `json:"emoji_list"`
+	MaskPosition *MaskPosition `json:"mask_position,omitempty"`
+	Keywords     []string      `json:"keywords,omitempty"`
+}
+
+// StickerSetOptions contains the optional parameters used in the CreateStickerSet method.
+type StickerSetOptions struct {
+	StickerType     StickerSetType `query:"sticker_type"`
+	NeedsRepainting bool           `query:"needs_repainting"`
+}
+
 // NewStickerSetOptions contains the optional parameters used in the CreateNewStickerSet method.
 type NewStickerSetOptions struct {
 	ContainsMasks bool         `query:"contains_masks"`
@@ -76,7 +118,7 @@ type StickerFile struct {
 	Type StickerType
 }
 
-// SendSticker is used to send static .WEBP or animated .TGS stickers.
+// SendSticker is used to send static .WEBP, animated .TGS or video .WEBM stickers.
 func (a API) SendSticker(stickerID string, chatID int64, opts *BaseOptions) (res APIResponseMessage, err error) {
 	var url = fmt.Sprintf(
 		"%ssendSticker?chat_id=%d&sticker=%s&%s",
@@ -86,7 +128,7 @@ func (a API) SendSticker(stickerID string, chatID int64, opts *BaseOptions) (res
 		querify(opts),
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -107,7 +149,7 @@ func (a API) GetStickerSet(name string) (res APIResponseStickerSet, err error) {
 		encode(name),
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -122,6 +164,7 @@ func (a API) GetStickerSet(name string) (res APIResponseStickerSet, err error) {
 
 // UploadStickerFile is used to upload a .PNG file with a sticker for later use in
 // CreateNewStickerSet and AddStickerToSet methods (can be used multiple times).
+// Use UploadStickerFileFormat to upload the stickers for CreateStickerSet and AddInputStickerToSet.
 func (a API) UploadStickerFile(userID int64, sticker StickerFile) (res APIResponseFile, err error) {
 	var url = fmt.Sprintf(
 		"%suploadStickerFile?user_id=%d",
@@ -129,7 +172,7 @@ func (a API) UploadStickerFile(userID int64, sticker StickerFile) (res APIRespon
 		userID,
 	)
 
-	cnt, err := sendFile(sticker.File, InputFile{}, url, string(sticker.Type))
+	cnt, err := a.sendFile(sticker.File, InputFile{}, url, string(sticker.Type))
 	if err != nil {
 		return
 	}
@@ -143,6 +186,7 @@ func (a API) UploadStickerFile(userID int64, sticker StickerFile) (res APIRespon
 }
 
 // CreateNewStickerSet is used to create a new sticker set owned by a user.
+// Use CreateStickerSet to create a set with multiple stickers or with custom emoji stickers.
 func (a API) CreateNewStickerSet(userID int64, name, title, emojis string, sticker StickerFile, opts *NewStickerSetOptions) (res APIResponseBase, err error) {
 	var url = fmt.Sprintf(
 		"%screateNewStickerSet?user_id=%d&name=%s&title=%s&emojis=%s&%s",
@@ -154,7 +198,7 @@ func (a API) CreateNewStickerSet(userID int64, name, title, emojis string, stick
 		querify(opts),
 	)
 
-	cnt, err := sendFile(sticker.File, InputFile{}, url, string(sticker.Type))
+	cnt, err := a.sendFile(sticker.File, InputFile{}, url, string(sticker.Type))
 	if err != nil {
 		return
 	}
@@ -178,7 +222,7 @@ func (a API) AddStickerToSet(userID int64, name, emojis string, sticker StickerF
 		querify(opts),
 	)
 
-	cnt, err := sendFile(sticker.File, InputFile{}, url, string(sticker.Type))
+	cnt, err := a.sendFile(sticker.File, InputFile{}, url, string(sticker.Type))
 	if err != nil {
 		return
 	}
@@ -200,7 +244,7 @@ func (a API) SetStickerPositionInSet(sticker string, position int) (res APIRespo
 		position,
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -221,7 +265,7 @@ func (a API) DeleteStickerFromSet(sticker string) (res APIResponseBase, err erro
 		encode(sticker),
 	)
 
-	cnt, err := sendGetRequest(url)
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
@@ -235,6 +279,7 @@ func (a API) DeleteStickerFromSet(sticker string) (res APIResponseBase, err erro
 }
 
 // SetStickerSetThumb is used to set the thumbnail of a sticker set.
+// Use SetStickerSetThumbnail for the sticker sets created with CreateStickerSet.
 func (a API) SetStickerSetThumb(name string, userID int64, thumb InputFile) (res APIResponseBase, err error) {
 	var url = fmt.Sprintf(
 		"%ssetStickerSetThumb?name=%s&user_id=%d",
@@ -243,7 +288,292 @@ func (a API) SetStickerSetThumb(name string, userID int64, thumb InputFile) (res
 		userID,
 	)
 
-	cnt, err := sendFile(thumb, InputFile{}, url, "thumb")
+	cnt, err := a.sendFile(thumb, InputFile{}, url, "thumb")
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(cnt, &res); err != nil {
+		return
+	}
+
+	err = check(res)
+	return
+}
+
+// UploadStickerFileFormat is used to upload a file with a sticker in the given format for later use in
+// the CreateStickerSet and AddInputStickerToSet methods (can be used multiple times).
+func (a API) UploadStickerFileFormat(userID int64, sticker InputFile, format StickerFormat) (res APIResponseFile, err error) {
+	var url = fmt.Sprintf(
+		"%suploadStickerFile?user_id=%d&sticker_format=%s",
+		a.base,
+		userID,
+		format,
+	)
+
+	cnt, err := a.sendFile(sticker, InputFile{}, url, "sticker")
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(cnt, &res); err != nil {
+		return
+	}
+
+	err = check(res)
+	return
+}
+
+// SetStickerSetThumbnail is used to set the thumbnail of a regular or mask sticker set in the given format,
+// which must match the format of the stickers in the set.
+// An empty InputFile removes the thumbnail, so that the first sticker of the set is used instead.
+func (a API) SetStickerSetThumbnail(name string, userID int64, thumbnail InputFile, format StickerFormat) (res APIResponseBool, err error) {
+	var (
+		cnt []byte
+		url = fmt.Sprintf(
+			"%ssetStickerSetThumbnail?name=%s&user_id=%d&format=%s",
+			a.base,
+			encode(name),
+			userID,
+			format,
+		)
+	)
+
+	if thumbnail.id == "" && thumbnail.path == "" && len(thumbnail.content) == 0 {
+		cnt, err = a.sendGetRequest(url)
+	} else {
+		cnt, err = a.sendFile(thumbnail, InputFile{}, url, "thumbnail")
+	}
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(cnt, &res); err != nil {
+		return
+	}
+
+	err = check(res)
+	return
+}
+
+// GetCustomEmojiStickers is used to get information about custom emoji stickers by their identifiers.
+func (a API) GetCustomEmojiStickers(customEmojiIDs ...string) (res APIResponseStickers, err error) {
+	jsn, _ := json.Marshal(customEmojiIDs)
+
+	var url = fmt.Sprintf(
+		"%sgetCustomEmojiStickers?custom_emoji_ids=%s",
+		a.base,
+		encode(string(jsn)),
+	)
+
+	cnt, err := a.sendGetRequest(url)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(cnt, &res); err != nil {
+		return
+	}
+
+	err = check(res)
+	return
+}
+
+// CreateStickerSet is used to create a new sticker set owned by a user, containing all the given stickers.
+// The stickers can be regular, mask or custom emoji stickers in any format, according to opts.
+func (a API) CreateStickerSet(userID int64, name, title string, stickers []InputSticker, opts *StickerSetOptions) (res APIResponseBool, err error) {
+	var url = fmt.Sprintf(
+		"%screateNewStickerSet?user_id=%d&name=%s&title=%s&%s",
+		a.base,
+		userID,
+		encode(name),
+		encode(title),
+		querify(opts),
+	)
+
+	cnt, err := a.sendStickers(url, "stickers", false, stickers...)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(cnt, &res); err != nil {
+		return
+	}
+
+	err = check(res)
+	return
+}
+
+// AddInputStickerToSet is used to add a new sticker described by an InputSticker to a set created by the bot.
+func (a API) AddInputStickerToSet(userID int64, name string, sticker InputSticker) (res APIResponseBool, err error) {
+	var url = fmt.Sprintf(
+		"%saddStickerToSet?user_id=%d&name=%s",
+		a.base,
+		userID,
+		encode(name),
+	)
+
+	cnt, err := a.sendStickers(url, "sticker", true, sticker)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(cnt, &res); err != nil {
+		return
+	}
+
+	err = check(res)
+	return
+}
+
+// sendStickers sends the given stickers as the JSON-serialized parameter with the given name,
+// uploading the files which aren't already on the Telegram servers.
+func (a API) sendStickers(url, param string, isSingleSticker bool, stickers ...InputSticker) (res []byte, err error) {
+	var (
+		env []interface{}
+		cnt []content
+		jsn []byte
+	)
+
+	for _, st := range stickers {
+		im, c, e := processMedia(st.Sticker, InputFile{})
+		if e != nil {
+			return nil, e
+		}
+
+		env = append(env, struct {
+			InputSticker
+			Sticker string `json:"sticker"`
+		}{st, im.media})
+		cnt = append(cnt, c...)
+	}
+
+	if isSingleSticker {
+		jsn, err = json.Marshal(env[0])
+	} else {
+		jsn, err = json.Marshal(env)
+	}
+
+	if err != nil {
+		return
+	}
+
+	url = fmt.Sprintf("%s&%s=%s", url, param, encode(string(jsn)))
+
+	if len(cnt) > 0 {
+		return a.sendPostRequest(url, cnt...)
+	}
+	return a.sendGetRequest(url)
+}
+
+// SetStickerEmojiList is used to change the list of emoji assigned to a regular or custom emoji sticker.
+// The sticker must belong to a sticker set created by the bot.
+func (a API) SetStickerEmojiList(sticker string, emojiList []string) (res APIResponseBool, err error) {
+	jsn, _ := json.Marshal(emojiList)
+
+	var url = fmt.Sprintf(
+		"%ssetStickerEmojiList?sticker=%s&emoji_list=%s",
+		a.base,
+		encode(sticker),
+		encode(string(jsn)),
+	)
+
+	cnt, err := a.sendGetRequest(url)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(cnt, &res); err != nil {
+		return
+	}
+
+	err = check(res)
+	return
+}
+
+// SetStickerKeywords is used to change search keywords assigned to a regular or custom emoji sticker.
+// The sticker must belong to a sticker set created by the bot, an empty list of keywords removes them.
+func (a API) SetStickerKeywords(sticker string, keywords []string) (res APIResponseBool, err error) {
+	jsn, _ := json.Marshal(keywords)
+
+	var url = fmt.Sprintf(
+		"%ssetStickerKeywords?sticker=%s&keywords=%s",
+		a.base,
+		encode(sticker),
+		encode(string(jsn)),
+	)
+
+	cnt, err := a.sendGetRequest(url)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(cnt, &res); err != nil {
+		return
+	}
+
+	err = check(res)
+	return
+}
+
+// SetStickerMaskPosition is used to change the mask position of a mask sticker.
+// The sticker must belong to a sticker set that was created by the bot, a nil maskPosition removes it.
+func (a API) SetStickerMaskPosition(sticker string, maskPosition *MaskPosition) (res APIResponseBool, err error) {
+	var url = fmt.Sprintf(
+		"%ssetStickerMaskPosition?sticker=%s",
+		a.base,
+		encode(sticker),
+	)
+
+	if maskPosition != nil {
+		jsn, _ := json.Marshal(maskPosition)
+		url = fmt.Sprintf("%s&mask_position=%s", url, encode(string(jsn)))
+	}
+
+	cnt, err := a.sendGetRequest(url)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(cnt, &res); err != nil {
+		return
+	}
+
+	err = check(res)
+	return
+}
+
+// SetStickerSetTitle is used to set the title of a created sticker set.
+func (a API) SetStickerSetTitle(name, title string) (res APIResponseBool, err error) {
+	var url = fmt.Sprintf(
+		"%ssetStickerSetTitle?name=%s&title=%s",
+		a.base,
+		encode(name),
+		encode(title),
+	)
+
+	cnt, err := a.sendGetRequest(url)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(cnt, &res); err != nil {
+		return
+	}
+
+	err = check(res)
+	return
+}
+
+// DeleteStickerSet is used to delete a sticker set that was created by the bot.
+func (a API) DeleteStickerSet(name string) (res APIResponseBool, err error) {
+	var url = fmt.Sprintf(
+		"%sdeleteStickerSet?name=%s",
+		a.base,
+		encode(name),
+	)
+
+	cnt, err := a.sendGetRequest(url)
 	if err != nil {
 		return
 	}
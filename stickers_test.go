@@ -19,6 +19,7 @@
 package echotron
 
 import (
+	"encoding/json"
 	"errors"
 	"testing"
 )
@@ -153,3 +154,158 @@ func TestSetStickerSetThumb(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestCreateStickerSet(t *testing.T) {
+	a, reqs := newMockAPI(t, "true")
+
+	_, err := a.CreateStickerSet(
+		chatID,
+		"emojipack_by_echotron_coverage_bot",
+		"Echotron Emoji Pack",
+		[]InputSticker{
+			{
+				Sticker:   NewInputFilePath("assets/tests/echotron_sticker.png"),
+				Format:    StaticFormat,
+				EmojiList: []string{"🤖"},
+				Keywords:  []string{"robot"},
+			},
+			{
+				Sticker:   NewInputFileID("CAACAgIAAxkBAAEB"),
+				Format:    VideoFormat,
+				EmojiList: []string{"🎥", "🤖"},
+			},
+		},
+		&StickerSetOptions{StickerType: CustomEmojiStickerSet},
+	)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := <-reqs
+	if req.method != "createNewStickerSet" || req.query.Get("sticker_type") != "custom_emoji" {
+		t.Fatalf("unexpected request %s %v", req.method, req.query)
+	}
+
+	var stickers []map[string]interface{}
+	if err = json.Unmarshal([]byte(req.query.Get("stickers")), &stickers); err != nil {
+		t.Fatal(err)
+	}
+	if len(stickers) != 2 || stickers[0]["sticker"] != "attach://echotron_sticker.png" || stickers[1]["sticker"] != "CAACAgIAAxkBAAEB" {
+		t.Fatalf("unexpected stickers %v", stickers)
+	}
+	if stickers[1]["format"] != "video" || len(stickers[1]["emoji_list"].([]interface{})) != 2 {
+		t.Fatalf("unexpected sticker %v", stickers[1])
+	}
+}
+
+func TestAddInputStickerToSet(t *testing.T) {
+	a, reqs := newMockAPI(t, "true")
+
+	_, err := a.AddInputStickerToSet(
+		chatID,
+		"emojipack_by_echotron_coverage_bot",
+		InputSticker{
+			Sticker:      NewInputFileID("CAACAgIAAxkBAAEB"),
+			Format:       StaticFormat,
+			EmojiList:    []string{"🤖"},
+			MaskPosition: &MaskPosition{Point: "eyes", Scale: 1},
+		},
+	)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var sticker map[string]interface{}
+	if err = json.Unmarshal([]byte((<-reqs).query.Get("sticker")), &sticker); err != nil {
+		t.Fatal(err)
+	}
+	if sticker["sticker"] != "CAACAgIAAxkBAAEB" || sticker["mask_position"] == nil {
+		t.Fatalf("unexpected sticker %v", sticker)
+	}
+}
+
+func TestGetCustomEmojiStickers(t *testing.T) {
+	a, reqs := newMockAPI(t, `[{"file_id":"a","file_unique_id":"b","type":"custom_emoji","custom_emoji_id":"123","is_video":true}]`)
+
+	res, err := a.GetCustomEmojiStickers("123")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ids := (<-reqs).query.Get("custom_emoji_ids"); ids != `["123"]` {
+		t.Fatalf("unexpected custom_emoji_ids %s", ids)
+	}
+	if len(res.Result) != 1 || res.Result[0].Type != CustomEmojiStickerSet || res.Result[0].CustomEmojiID != "123" || !res.Result[0].IsVideo {
+		t.Fatalf("unexpected result %+v", res.Result)
+	}
+}
+
+func TestStickerSetEdits(t *testing.T) {
+	a, reqs := newMockAPI(t, "true")
+
+	expect := func(method, key, value string) {
+		req := <-reqs
+		if req.method != method || req.query.Get(key) != value {
+			t.Fatalf("unexpected request %s %v", req.method, req.query)
+		}
+	}
+
+	if _, err := a.SetStickerEmojiList("sticker", []string{"🤖", "👾"}); err != nil {
+		t.Fatal(err)
+	}
+	expect("setStickerEmojiList", "emoji_list", `["🤖","👾"]`)
+
+	if _, err := a.SetStickerKeywords("sticker", []string{"robot"}); err != nil {
+		t.Fatal(err)
+	}
+	expect("setStickerKeywords", "keywords", `["robot"]`)
+
+	if _, err := a.SetStickerMaskPosition("sticker", nil); err != nil {
+		t.Fatal(err)
+	}
+	expect("setStickerMaskPosition", "mask_position", "")
+
+	if _, err := a.SetStickerSetTitle("pack", "New Title"); err != nil {
+		t.Fatal(err)
+	}
+	expect("setStickerSetTitle", "title", "New Title")
+
+	if _, err := a.DeleteStickerSet("pack"); err != nil {
+		t.Fatal(err)
+	}
+	expect("deleteStickerSet", "name", "pack")
+}
+
+func TestUploadStickerFileFormat(t *testing.T) {
+	a, reqs := newMockAPI(t, `{"file_id":"sticker"}`)
+
+	res, err := a.UploadStickerFileFormat(chatID, NewInputFileBytes("sticker.webm", []byte("webm")), VideoFormat)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := <-reqs
+	if req.method != "uploadStickerFile" || req.query.Get("sticker_format") != "video" || res.Result.FileID != "sticker" {
+		t.Fatalf("unexpected request %s %v", req.method, req.query)
+	}
+}
+
+func TestSetStickerSetThumbnail(t *testing.T) {
+	a, reqs := newMockAPI(t, "true")
+
+	if _, err := a.SetStickerSetThumbnail("pack_by_echotron_coverage_bot", chatID, NewInputFileID("thumb"), AnimatedFormat); err != nil {
+		t.Fatal(err)
+	}
+	if req := <-reqs; req.method != "setStickerSetThumbnail" || req.query.Get("thumbnail") != "thumb" || req.query.Get("format") != "animated" {
+		t.Fatalf("unexpected request %s %v", req.method, req.query)
+	}
+
+	if _, err := a.SetStickerSetThumbnail("pack_by_echotron_coverage_bot", chatID, InputFile{}, StaticFormat); err != nil {
+		t.Fatal(err)
+	}
+	if req := <-reqs; req.query.Get("thumbnail") != "" {
+		t.Fatalf("unexpected request %s %v", req.method, req.query)
+	}
+}
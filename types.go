@@ -23,17 +23,27 @@ import "encoding/json"
 // Update represents an incoming update.
 // At most one of the optional parameters can be present in any given update.
 type Update struct {
-	ID                 int                 `json:"update_id"`
-	Message            *Message            `json:"message,omitempty"`
-	EditedMessage      *Message            `json:"edited_message,omitempty"`
-	ChannelPost        *Message            `json:"channel_post,omitempty"`
-	EditedChannelPost  *Message            `json:"edited_channel_post,omitempty"`
-	InlineQuery        *InlineQuery        `json:"inline_query,omitempty"`
-	ChosenInlineResult *ChosenInlineResult `json:"chosen_inline_result,omitempty"`
-	CallbackQuery      *CallbackQuery      `json:"callback_query,omitempty"`
-	MyChatMember       *ChatMemberUpdated  `json:"my_chat_member,omitempty"`
-	ChatMember         *ChatMemberUpdated  `json:"chat_member,omitempty"`
-	ChatJoinRequest    *ChatJoinRequest    `json:"chat_join_request,omitempty"`
+	ID                   int                          `json:"update_id"`
+	Message              *Message                     `json:"message,omitempty"`
+	EditedMessage        *Message                     `json:"edited_message,omitempty"`
+	ChannelPost          *Message                     `json:"channel_post,omitempty"`
+	EditedChannelPost    *Message                     `json:"edited_channel_post,omitempty"`
+	MessageReaction      *MessageReactionUpdated      `json:"message_reaction,omitempty"`
+	MessageReactionCount *MessageReactionCountUpdated `json:"message_reaction_count,omitempty"`
+	InlineQuery          *InlineQuery                 `json:"inline_query,omitempty"`
+	ChosenInlineResult   *ChosenInlineResult          `json:"chosen_inline_result,omitempty"`
+	CallbackQuery        *CallbackQuery               `json:"callback_query,omitempty"`
+	ShippingQuery        *ShippingQuery               `json:"shipping_query,omitempty"`
+	PreCheckoutQuery     *PreCheckoutQuery            `json:"pre_checkout_query,omitempty"`
+	Poll                 *Poll                        `json:"poll,omitempty"`
+	PollAnswer           *PollAnswer                  `json:"poll_answer,omitempty"`
+	MyChatMember         *ChatMemberUpdated           `json:"my_chat_member,omitempty"`
+	ChatMember           *ChatMemberUpdated           `json:"chat_member,omitempty"`
+	ChatJoinRequest      *ChatJoinRequest             `json:"chat_join_request,omitempty"`
+
+	// MediaGroup contains all the messages of a media group aggregated by the Dispatcher,
+	// see Dispatcher.SetMediaGroupWindow. It's never sent by Telegram.
+	MediaGroup []*Message `json:"-"`
 }
 
 // WebhookInfo contains information about the current status of a webhook.
@@ -103,6 +113,26 @@ func (a APIResponseMessage) Base() APIResponseBase {
 	return a.APIResponseBase
 }
 
+// UnmarshalJSON decodes the response, leaving Result nil when Telegram returns True
+// instead of a Message, as it happens when editing inline messages.
+func (a *APIResponseMessage) UnmarshalJSON(b []byte) error {
+	var raw struct {
+		Result json.RawMessage `json:"result,omitempty"`
+		APIResponseBase
+	}
+
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	a.APIResponseBase = raw.APIResponseBase
+	a.Result = nil
+	if len(raw.Result) == 0 || string(raw.Result) == "true" {
+		return nil
+	}
+	return json.Unmarshal(raw.Result, &a.Result)
+}
+
 // APIResponseMessageArray represents the incoming response from Telegram servers.
 // Used by all methods that return an array of Message objects on success.
 type APIResponseMessageArray struct {
@@ -127,6 +157,18 @@ func (a APIResponseMessageID) Base() APIResponseBase {
 	return a.APIResponseBase
 }
 
+// APIResponseMessageIDs represents the incoming response from Telegram servers.
+// Used by all methods that return an array of MessageID objects on success.
+type APIResponseMessageIDs struct {
+	Result []*MessageID `json:"result,omitempty"`
+	APIResponseBase
+}
+
+// Returns the contained object of type APIResponseBase.
+func (a APIResponseMessageIDs) Base() APIResponseBase {
+	return a.APIResponseBase
+}
+
 // APIResponseCommands represents the incoming response from Telegram servers.
 // Used by all methods that return an array of BotCommand objects on success.
 type APIResponseCommands struct {
@@ -163,6 +205,18 @@ func (a APIResponseString) Base() APIResponseBase {
 	return a.APIResponseBase
 }
 
+// APIResponseSentWebAppMessage represents the incoming response from Telegram servers.
+// Used by all methods that return a SentWebAppMessage object on success.
+type APIResponseSentWebAppMessage struct {
+	Result *SentWebAppMessage `json:"result,omitempty"`
+	APIResponseBase
+}
+
+// Returns the contained object of type APIResponseBase.
+func (a APIResponseSentWebAppMessage) Base() APIResponseBase {
+	return a.APIResponseBase
+}
+
 // APIResponseChat represents the incoming response from Telegram servers.
 // Used by all methods that return a Chat object on success.
 type APIResponseChat struct {
@@ -187,6 +241,18 @@ func (a APIResponseInviteLink) Base() APIResponseBase {
 	return a.APIResponseBase
 }
 
+// APIResponseStickers represents the incoming response from Telegram servers.
+// Used by all methods that return an array of Sticker objects on success.
+type APIResponseStickers struct {
+	Result []*Sticker `json:"result,omitempty"`
+	APIResponseBase
+}
+
+// Returns the contained object of type APIResponseBase.
+func (a APIResponseStickers) Base() APIResponseBase {
+	return a.APIResponseBase
+}
+
 // APIResponseStickerSet represents the incoming response from Telegram servers.
 // Used by all methods that return a StickerSet object on success.
 type APIResponseStickerSet struct {
@@ -235,6 +301,78 @@ func (a APIResponseAdministrators) Base() APIResponseBase {
 	return a.APIResponseBase
 }
 
+// APIResponseMenuButton represents the incoming response from Telegram servers.
+// Used by all methods that return a MenuButton object on success.
+type APIResponseMenuButton struct {
+	Result *MenuButton `json:"result,omitempty"`
+	APIResponseBase
+}
+
+// Returns the contained object of type APIResponseBase.
+func (a APIResponseMenuButton) Base() APIResponseBase {
+	return a.APIResponseBase
+}
+
+// APIResponseChatAdministratorRights represents the incoming response from Telegram servers.
+// Used by all methods that return a ChatAdministratorRights object on success.
+type APIResponseChatAdministratorRights struct {
+	Result *ChatAdministratorRights `json:"result,omitempty"`
+	APIResponseBase
+}
+
+// Returns the contained object of type APIResponseBase.
+func (a APIResponseChatAdministratorRights) Base() APIResponseBase {
+	return a.APIResponseBase
+}
+
+// APIResponseForumTopic represents the incoming response from Telegram servers.
+// Used by all methods that return a ForumTopic object on success.
+type APIResponseForumTopic struct {
+	Result *ForumTopic `json:"result,omitempty"`
+	APIResponseBase
+}
+
+// Returns the contained object of type APIResponseBase.
+func (a APIResponseForumTopic) Base() APIResponseBase {
+	return a.APIResponseBase
+}
+
+// APIResponseBotName represents the incoming response from Telegram servers.
+// Used by all methods that return a BotName object on success.
+type APIResponseBotName struct {
+	Result *BotName `json:"result,omitempty"`
+	APIResponseBase
+}
+
+// Returns the contained object of type APIResponseBase.
+func (a APIResponseBotName) Base() APIResponseBase {
+	return a.APIResponseBase
+}
+
+// APIResponseBotDescription represents the incoming response from Telegram servers.
+// Used by all methods that return a BotDescription object on success.
+type APIResponseBotDescription struct {
+	Result *BotDescription `json:"result,omitempty"`
+	APIResponseBase
+}
+
+// Returns the contained object of type APIResponseBase.
+func (a APIResponseBotDescription) Base() APIResponseBase {
+	return a.APIResponseBase
+}
+
+// APIResponseBotShortDescription represents the incoming response from Telegram servers.
+// Used by all methods that return a BotShortDescription object on success.
+type APIResponseBotShortDescription struct {
+	Result *BotShortDescription `json:"result,omitempty"`
+	APIResponseBase
+}
+
+// Returns the contained object of type APIResponseBase.
+func (a APIResponseBotShortDescription) Base() APIResponseBase {
+	return a.APIResponseBase
+}
+
 // APIResponseChatMember represents the incoming response from Telegram servers.
 // Used by all methods that return a ChatMember object on success.
 type APIResponseChatMember struct {
@@ -310,6 +448,7 @@ type Chat struct {
 	ID                    int64            `json:"id"`
 	Type                  string           `json:"type"`
 	Title                 string           `json:"title,omitempty"`
+	IsForum               bool             `json:"is_forum,omitempty"`
 	Username              string           `json:"username,omitempty"`
 	FirstName             string           `json:"first_name,omitempty"`
 	LastName              string           `json:"last_name,omitempty"`
@@ -332,6 +471,7 @@ type Chat struct {
 // Message represents a message.
 type Message struct {
 	ID                            int                            `json:"message_id"`
+	MessageThreadID               int                            `json:"message_thread_id,omitempty"`
 	From                          *User                          `json:"from,omitempty"`
 	SenderChat                    *Chat                          `json:"sender_chat,omitempty"`
 	Date                          int                            `json:"date"`
@@ -342,8 +482,11 @@ type Message struct {
 	ForwardSignature              string                         `json:"forward_signature,omitempty"`
 	ForwardSenderName             string                         `json:"forward_sender_name,omitempty"`
 	ForwardDate                   int                            `json:"forward_date,omitempty"`
+	IsTopicMessage                bool                           `json:"is_topic_message,omitempty"`
 	IsAutomaticForward            bool                           `json:"is_automatic_forward,omitempty"`
 	ReplyToMessage                *Message                       `json:"reply_to_message,omitempty"`
+	ExternalReply                 *ExternalReplyInfo             `json:"external_reply,omitempty"`
+	Quote                         *TextQuote                     `json:"quote,omitempty"`
 	ViaBot                        *User                          `json:"via_bot,omitempty"`
 	EditDate                      int                            `json:"edit_date,omitempty"`
 	HasProtectedContent           bool                           `json:"has_protected_content,omitempty"`
@@ -351,6 +494,7 @@ type Message struct {
 	AuthorSignature               string                         `json:"author_signature,omitempty"`
 	Text                          string                         `json:"text,omitempty"`
 	Entities                      []*MessageEntity               `json:"entities,omitempty"`
+	LinkPreviewOptions            *LinkPreviewOptions            `json:"link_preview_options,omitempty"`
 	Animation                     *Animation                     `json:"animation,omitempty"`
 	Audio                         *Audio                         `json:"audio,omitempty"`
 	Document                      *Document                      `json:"document,omitempty"`
@@ -379,14 +523,105 @@ type Message struct {
 	MigrateToChatID               int                            `json:"migrate_to_chat_id,omitempty"`
 	MigrateFromChatID             int                            `json:"migrate_from_chat_id,omitempty"`
 	PinnedMessage                 *Message                       `json:"pinned_message,omitempty"`
+	Invoice                       *Invoice                       `json:"invoice,omitempty"`
+	SuccessfulPayment             *SuccessfulPayment             `json:"successful_payment,omitempty"`
+	UsersShared                   *UsersShared                   `json:"users_shared,omitempty"`
+	ChatShared                    *ChatShared                    `json:"chat_shared,omitempty"`
 	ConnectedWebsite              string                         `json:"connected_website,omitempty"`
+	WebAppData                    *WebAppData                    `json:"web_app_data,omitempty"`
 	ProximityAlertTriggered       *ProximityAlertTriggered       `json:"proximity_alert_triggered,omitempty"`
+	ForumTopicCreated             *ForumTopicCreated             `json:"forum_topic_created,omitempty"`
+	ForumTopicEdited              *ForumTopicEdited              `json:"forum_topic_edited,omitempty"`
+	ForumTopicClosed              *ForumTopicClosed              `json:"forum_topic_closed,omitempty"`
+	ForumTopicReopened            *ForumTopicReopened            `json:"forum_topic_reopened,omitempty"`
+	GeneralForumTopicHidden       *GeneralForumTopicHidden       `json:"general_forum_topic_hidden,omitempty"`
+	GeneralForumTopicUnhidden     *GeneralForumTopicUnhidden     `json:"general_forum_topic_unhidden,omitempty"`
 	VoiceChatStarted              *VoiceChatStarted              `json:"voice_chat_started,omitempty"`
 	VoiceChatEnded                *VoiceChatEnded                `json:"voice_chat_ended,omitempty"`
 	VoiceChatParticipantsInvited  *VoiceChatParticipantsInvited  `json:"voice_chat_participants_invited,omitempty"`
 	ReplyMarkup                   *InlineKeyboardMarkup          `json:"reply_markup,omitempty"`
 }
 
+// LinkPreviewOptions describes the options used for link preview generation.
+type LinkPreviewOptions struct {
+	IsDisabled       bool   `json:"is_disabled,omitempty"`
+	URL              string `json:"url,omitempty"`
+	PreferSmallMedia bool   `json:"prefer_small_media,omitempty"`
+	PreferLargeMedia bool   `json:"prefer_large_media,omitempty"`
+	ShowAboveText    bool   `json:"show_above_text,omitempty"`
+}
+
+// ReplyParameters describes the message to reply to, optionally quoting part of it
+// or belonging to a chat other than the one the reply is sent to.
+// When set, it takes the place of the ReplyToMessageID and AllowSendingWithoutReply options.
+type ReplyParameters struct {
+	MessageID                int             `json:"message_id"`
+	ChatID                   int64           `json:"chat_id,omitempty"`
+	AllowSendingWithoutReply bool            `json:"allow_sending_without_reply,omitempty"`
+	Quote                    string          `json:"quote,omitempty"`
+	QuoteParseMode           ParseMode       `json:"quote_parse_mode,omitempty"`
+	QuoteEntities            []MessageEntity `json:"quote_entities,omitempty"`
+	QuotePosition            int             `json:"quote_position,omitempty"`
+}
+
+// TextQuote contains information about the quoted part of a message that is replied to by the given message.
+type TextQuote struct {
+	Text     string           `json:"text"`
+	Entities []*MessageEntity `json:"entities,omitempty"`
+	Position int              `json:"position"`
+	IsManual bool             `json:"is_manual,omitempty"`
+}
+
+// MessageOriginType is a custom type for the various types of message origin.
+type MessageOriginType string
+
+// These are all the possible types of message origin.
+const (
+	UserOrigin       MessageOriginType = "user"
+	HiddenUserOrigin                   = "hidden_user"
+	ChatOrigin                         = "chat"
+	ChannelOrigin                      = "channel"
+)
+
+// MessageOrigin describes the origin of a message.
+// SenderUser is set for the UserOrigin type, SenderUserName for HiddenUserOrigin,
+// SenderChat for ChatOrigin and Chat and MessageID for ChannelOrigin.
+type MessageOrigin struct {
+	Type            MessageOriginType `json:"type"`
+	Date            int               `json:"date"`
+	SenderUser      *User             `json:"sender_user,omitempty"`
+	SenderUserName  string            `json:"sender_user_name,omitempty"`
+	SenderChat      *Chat             `json:"sender_chat,omitempty"`
+	Chat            *Chat             `json:"chat,omitempty"`
+	MessageID       int               `json:"message_id,omitempty"`
+	AuthorSignature string            `json:"author_signature,omitempty"`
+}
+
+// ExternalReplyInfo contains information about a message that is being replied to,
+// which may come from another chat or forum topic.
+type ExternalReplyInfo struct {
+	Origin             *MessageOrigin      `json:"origin"`
+	Chat               *Chat               `json:"chat,omitempty"`
+	MessageID          int                 `json:"message_id,omitempty"`
+	LinkPreviewOptions *LinkPreviewOptions `json:"link_preview_options,omitempty"`
+	Animation          *Animation          `json:"animation,omitempty"`
+	Audio              *Audio              `json:"audio,omitempty"`
+	Document           *Document           `json:"document,omitempty"`
+	Photo              []*PhotoSize        `json:"photo,omitempty"`
+	Sticker            *Sticker            `json:"sticker,omitempty"`
+	Video              *Video              `json:"video,omitempty"`
+	VideoNote          *VideoNote          `json:"video_note,omitempty"`
+	Voice              *Voice              `json:"voice,omitempty"`
+	HasMediaSpoiler    bool                `json:"has_media_spoiler,omitempty"`
+	Contact            *Contact            `json:"contact,omitempty"`
+	Dice               *Dice               `json:"dice,omitempty"`
+	Game               *Game               `json:"game,omitempty"`
+	Invoice            *Invoice            `json:"invoice,omitempty"`
+	Location           *Location           `json:"location,omitempty"`
+	Poll               *Poll               `json:"poll,omitempty"`
+	Venue              *Venue              `json:"venue,omitempty"`
+}
+
 // MessageID represents a unique message identifier.
 type MessageID struct {
 	MessageID int `json:"message_id"`
@@ -395,11 +630,12 @@ type MessageID struct {
 // MessageEntity represents one special entity in a text message.
 // For example, hashtags, usernames, URLs, etc.
 type MessageEntity struct {
-	Type   MessageEntityType `json:"type"`
-	Offset int               `json:"offset"`
-	Length int               `json:"length"`
-	URL    string            `json:"url,omitempty"`
-	User   *User             `json:"user,omitempty"`
+	Type     MessageEntityType `json:"type"`
+	Offset   int               `json:"offset"`
+	Length   int               `json:"length"`
+	URL      string            `json:"url,omitempty"`
+	User     *User             `json:"user,omitempty"`
+	Language string            `json:"language,omitempty"`
 }
 
 // PhotoSize represents one size of a photo or a file / sticker thumbnail.
@@ -552,6 +788,30 @@ type ProximityAlertTriggered struct {
 	Distance int   `json:"distance"`
 }
 
+// SharedUser contains information about a user that was shared with the bot using a KeyboardButtonRequestUsers button.
+type SharedUser struct {
+	UserID    int64        `json:"user_id"`
+	FirstName string       `json:"first_name,omitempty"`
+	LastName  string       `json:"last_name,omitempty"`
+	Username  string       `json:"username,omitempty"`
+	Photo     []*PhotoSize `json:"photo,omitempty"`
+}
+
+// UsersShared contains information about the users whose identifiers were shared with the bot using a KeyboardButtonRequestUsers button.
+type UsersShared struct {
+	RequestID int          `json:"request_id"`
+	Users     []SharedUser `json:"users"`
+}
+
+// ChatShared contains information about a chat whose identifier was shared with the bot using a KeyboardButtonRequestChat button.
+type ChatShared struct {
+	RequestID int          `json:"request_id"`
+	ChatID    int64        `json:"chat_id"`
+	Title     string       `json:"title,omitempty"`
+	Username  string       `json:"username,omitempty"`
+	Photo     []*PhotoSize `json:"photo,omitempty"`
+}
+
 // MessageAutoDeleteTimerChanged represents a service message about a change in auto-delete timer settings.
 type MessageAutoDeleteTimerChanged struct {
 	MessageAutoDeleteTime int `json:"message_auto_delete_time"`
@@ -649,6 +909,7 @@ type ChatMember struct {
 	CanChangeInfo         bool   `json:"can_change_info,omitempty"`
 	CanInviteUsers        bool   `json:"can_invite_users,omitempty"`
 	CanPinMessages        bool   `json:"can_pin_messages,omitempty"`
+	CanManageTopics       bool   `json:"can_manage_topics,omitempty"`
 	IsMember              bool   `json:"is_member,omitempty"`
 	CanSendMessages       bool   `json:"can_send_messages,omitempty"`
 	CanSendMediaMessages  bool   `json:"can_send_media_messages,omitempty"`
@@ -678,6 +939,7 @@ type ChatPermissions struct {
 	CanChangeInfo         bool `json:"can_change_info,omitempty"`
 	CanInviteUsers        bool `json:"can_invite_users,omitempty"`
 	CanPinMessages        bool `json:"can_pin_messages,omitempty"`
+	CanManageTopics       bool `json:"can_manage_topics,omitempty"`
 }
 
 // ChatLocation represents a location to which a chat is connected.
@@ -686,6 +948,21 @@ type ChatLocation struct {
 	Address  string    `json:"address"`
 }
 
+// BotName represents the bot's name.
+type BotName struct {
+	Name string `json:"name"`
+}
+
+// BotDescription represents the bot's description.
+type BotDescription struct {
+	Description string `json:"description"`
+}
+
+// BotShortDescription represents the bot's short description.
+type BotShortDescription struct {
+	ShortDescription string `json:"short_description"`
+}
+
 // BotCommand represents a bot command.
 type BotCommand struct {
 	Command     string `json:"command"`
@@ -919,6 +1196,42 @@ type BotCommandScope struct {
 	UserID int64               `query:"user_id"`
 }
 
+// MenuButtonType is a custom type for the various menu button types.
+type MenuButtonType string
+
+// These are all the various menu button types.
+const (
+	MBTCommands MenuButtonType = "commands"
+	MBTWebApp                  = "web_app"
+	MBTDefault                 = "default"
+)
+
+// MenuButton describes the bot's menu button in a private chat.
+// If a menu button other than MBTDefault is set for a private chat, then it is applied in the chat.
+// Otherwise the default menu button is applied.
+// By default, the menu button opens the list of bot commands.
+type MenuButton struct {
+	Type   MenuButtonType `json:"type"`
+	Text   string         `json:"text,omitempty"`
+	WebApp *WebAppInfo    `json:"web_app,omitempty"`
+}
+
+// ChatAdministratorRights represents the rights of an administrator in a chat.
+type ChatAdministratorRights struct {
+	IsAnonymous         bool `json:"is_anonymous"`
+	CanManageChat       bool `json:"can_manage_chat"`
+	CanDeleteMessages   bool `json:"can_delete_messages"`
+	CanManageVideoChats bool `json:"can_manage_video_chats"`
+	CanRestrictMembers  bool `json:"can_restrict_members"`
+	CanPromoteMembers   bool `json:"can_promote_members"`
+	CanChangeInfo       bool `json:"can_change_info"`
+	CanInviteUsers      bool `json:"can_invite_users"`
+	CanPostMessages     bool `json:"can_post_messages,omitempty"`
+	CanEditMessages     bool `json:"can_edit_messages,omitempty"`
+	CanPinMessages      bool `json:"can_pin_messages,omitempty"`
+	CanManageTopics     bool `json:"can_manage_topics,omitempty"`
+}
+
 // PermissionOptions is a custom type used to allow proper serialization of ChatPermissions-type parameters in some methods.
 type PermissionOptions struct {
 	Permissions ChatPermissions `json:"permissions"`
@@ -1,6 +1,9 @@
 package echotron
 
-import "testing"
+import (
+	"encoding/json"
+	"testing"
+)
 
 func TestAPIResponseBase(_ *testing.T) {
 	a := APIResponseBase{}
@@ -22,6 +25,18 @@ func TestAPIResponseMessage(_ *testing.T) {
 	a.Base()
 }
 
+func TestAPIResponseMessageTrue(t *testing.T) {
+	var res APIResponseMessage
+
+	if err := json.Unmarshal([]byte(`{"ok":true,"result":true}`), &res); err != nil || !res.Ok || res.Result != nil {
+		t.Fatalf("unexpected response %+v %v", res, err)
+	}
+
+	if err := json.Unmarshal([]byte(`{"ok":true,"result":{"message_id":1}}`), &res); err != nil || res.Result == nil || res.Result.ID != 1 {
+		t.Fatalf("unexpected response %+v %v", res, err)
+	}
+}
+
 func TestAPIResponseMessageArray(_ *testing.T) {
 	a := APIResponseMessageArray{}
 	a.Base()
@@ -47,6 +62,11 @@ func TestAPIResponseString(_ *testing.T) {
 	a.Base()
 }
 
+func TestAPIResponseSentWebAppMessage(_ *testing.T) {
+	a := APIResponseSentWebAppMessage{}
+	a.Base()
+}
+
 func TestAPIResponseChat(_ *testing.T) {
 	a := APIResponseChat{}
 	a.Base()
@@ -57,6 +77,11 @@ func TestAPIResponseInviteLink(_ *testing.T) {
 	a.Base()
 }
 
+func TestAPIResponseStickers(_ *testing.T) {
+	a := APIResponseStickers{}
+	a.Base()
+}
+
 func TestAPIResponseStickerSet(_ *testing.T) {
 	a := APIResponseStickerSet{}
 	a.Base()
@@ -77,6 +102,41 @@ func TestAPIResponseAdministrators(_ *testing.T) {
 	a.Base()
 }
 
+func TestAPIResponseMenuButton(_ *testing.T) {
+	a := APIResponseMenuButton{}
+	a.Base()
+}
+
+func TestAPIResponseChatAdministratorRights(_ *testing.T) {
+	a := APIResponseChatAdministratorRights{}
+	a.Base()
+}
+
+func TestAPIResponseForumTopic(_ *testing.T) {
+	a := APIResponseForumTopic{}
+	a.Base()
+}
+
+func TestAPIResponseBotName(_ *testing.T) {
+	a := APIResponseBotName{}
+	a.Base()
+}
+
+func TestAPIResponseBotDescription(_ *testing.T) {
+	a := APIResponseBotDescription{}
+	a.Base()
+}
+
+func TestAPIResponseBotShortDescription(_ *testing.T) {
+	a := APIResponseBotShortDescription{}
+	a.Base()
+}
+
+func TestAPIResponseMessageIDs(_ *testing.T) {
+	a := APIResponseMessageIDs{}
+	a.Base()
+}
+
 func TestAPIResponseChatMember(_ *testing.T) {
 	a := APIResponseChatMember{}
 	a.Base()
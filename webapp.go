@@ -0,0 +1,217 @@
+/*
+ * Echotron
+ * Copyright (C) 2018-2022 The Echotron Devs
+ *
+ * Echotron is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Echotron is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package echotron
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidHash is returned when the hash of the data received from Telegram doesn't match its content.
+	ErrInvalidHash = errors.New("invalid hash")
+	// ErrAuthExpired is returned when the auth_date of the data received from Telegram is too old.
+	ErrAuthExpired = errors.New("authentication data expired")
+)
+
+// WebAppInfo contains information about a Web App.
+type WebAppInfo struct {
+	URL string `json:"url"`
+}
+
+// WebAppData contains data sent from a Web App to the bot.
+type WebAppData struct {
+	Data       string `json:"data"`
+	ButtonText string `json:"button_text"`
+}
+
+// SentWebAppMessage contains information about an inline message sent by a Web App on behalf of a user.
+type SentWebAppMessage struct {
+	InlineMessageID string `json:"inline_message_id,omitempty"`
+}
+
+// WebAppUser contains the data of a Web App user.
+type WebAppUser struct {
+	ID           int64  `json:"id"`
+	IsBot        bool   `json:"is_bot,omitempty"`
+	FirstName    string `json:"first_name"`
+	LastName     string `json:"last_name,omitempty"`
+	Username     string `json:"username,omitempty"`
+	LanguageCode string `json:"language_code,omitempty"`
+	IsPremium    bool   `json:"is_premium,omitempty"`
+	PhotoURL     string `json:"photo_url,omitempty"`
+}
+
+// WebAppChat represents a chat in the data passed to a Web App.
+type WebAppChat struct {
+	ID       int64  `json:"id"`
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Username string `json:"username,omitempty"`
+	PhotoURL string `json:"photo_url,omitempty"`
+}
+
+// WebAppInitData contains the data passed to a Web App when it's opened, available in the
+// Telegram.WebApp.initData field on the client side.
+type WebAppInitData struct {
+	QueryID      string
+	User         *WebAppUser
+	Receiver     *WebAppUser
+	Chat         *WebAppChat
+	ChatType     string
+	ChatInstance string
+	StartParam   string
+	CanSendAfter int
+	AuthDate     time.Time
+	Hash         string
+}
+
+// ParseWebAppInitData validates the init data of a Web App received by the backend of the Web App
+// with the token of the bot, as described in the Telegram documentation, and returns its parsed content.
+// The data is rejected with ErrAuthExpired if its auth_date is older than maxAge, a maxAge of 0 disables the check.
+func ParseWebAppInitData(initData, token string, maxAge time.Duration) (*WebAppInitData, error) {
+	vals, err := url.ParseQuery(initData)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := hmacSHA256([]byte("WebAppData"), []byte(token))
+	if err = checkDataHash(vals, secret); err != nil {
+		return nil, err
+	}
+
+	authDate, err := checkAuthDate(vals.Get("auth_date"), maxAge)
+	if err != nil {
+		return nil, err
+	}
+
+	var data = &WebAppInitData{
+		QueryID:      vals.Get("query_id"),
+		ChatType:     vals.Get("chat_type"),
+		ChatInstance: vals.Get("chat_instance"),
+		StartParam:   vals.Get("start_param"),
+		AuthDate:     authDate,
+		Hash:         vals.Get("hash"),
+	}
+
+	if v := vals.Get("can_send_after"); v != "" {
+		if data.CanSendAfter, err = strconv.Atoi(v); err != nil {
+			return nil, err
+		}
+	}
+
+	for key, dst := range map[string]interface{}{
+		"user":     &data.User,
+		"receiver": &data.Receiver,
+		"chat":     &data.Chat,
+	} {
+		if v := vals.Get(key); v != "" {
+			if err = json.Unmarshal([]byte(v), dst); err != nil {
+				return nil, fmt.Errorf("invalid %s field: %w", key, err)
+			}
+		}
+	}
+
+	return data, nil
+}
+
+// checkDataHash checks that the hash field of the values matches the HMAC-SHA256 signature
+// of the data-check-string made of all the other fields, computed with the given secret key.
+func checkDataHash(vals url.Values, secret []byte) error {
+	hash := vals.Get("hash")
+	if hash == "" {
+		return ErrInvalidHash
+	}
+
+	var fields []string
+	for k := range vals {
+		if k != "hash" {
+			fields = append(fields, k+"="+vals.Get(k))
+		}
+	}
+	sort.Strings(fields)
+
+	expected := hex.EncodeToString(hmacSHA256(secret, []byte(strings.Join(fields, "\n"))))
+	if !hmac.Equal([]byte(strings.ToLower(hash)), []byte(expected)) {
+		return ErrInvalidHash
+	}
+	return nil
+}
+
+// checkAuthDate parses the auth_date field and checks that it isn't older than maxAge, unless maxAge is 0.
+func checkAuthDate(s string, maxAge time.Duration) (time.Time, error) {
+	unix, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid auth_date: %w", err)
+	}
+
+	authDate := time.Unix(unix, 0)
+	if maxAge > 0 && time.Since(authDate) > maxAge {
+		return authDate, ErrAuthExpired
+	}
+	return authDate, nil
+}
+
+func hmacSHA256(key, data []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// AnswerWebAppQuery is used to set the result of an interaction with a Web App and
+// send a corresponding message on behalf of the user to the chat from which the query originated.
+func (a API) AnswerWebAppQuery(webAppQueryID string, result InlineQueryResult) (res APIResponseSentWebAppMessage, err error) {
+	results, err := prepareInlineResults([]InlineQueryResult{result})
+	if err != nil {
+		return
+	}
+
+	jsn, err := json.Marshal(results[0])
+	if err != nil {
+		return
+	}
+
+	var url = fmt.Sprintf(
+		"%sanswerWebAppQuery?web_app_query_id=%s&result=%s",
+		a.base,
+		encode(webAppQueryID),
+		encode(string(jsn)),
+	)
+
+	cnt, err := a.sendGetRequest(url)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(cnt, &res); err != nil {
+		return
+	}
+
+	err = check(res)
+	return
+}
@@ -0,0 +1,88 @@
+package echotron
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// signWebAppData returns the init data made of the given values signed like Telegram does.
+func signWebAppData(vals url.Values, token string) string {
+	var fields []string
+	for k := range vals {
+		fields = append(fields, k+"="+vals.Get(k))
+	}
+	sort.Strings(fields)
+
+	secret := hmac.New(sha256.New, []byte("WebAppData"))
+	secret.Write([]byte(token))
+
+	mac := hmac.New(sha256.New, secret.Sum(nil))
+	mac.Write([]byte(strings.Join(fields, "\n")))
+	vals.Set("hash", hex.EncodeToString(mac.Sum(nil)))
+	return vals.Encode()
+}
+
+func TestParseWebAppInitData(t *testing.T) {
+	now := time.Now().Unix()
+	initData := signWebAppData(url.Values{
+		"query_id":  {"AAHdF6IQAAAAAN0XohDhrOrc"},
+		"user":      {`{"id":279058397,"first_name":"Vladislav","username":"vdkfrost","language_code":"ru","is_premium":true}`},
+		"auth_date": {strconv.FormatInt(now, 10)},
+	}, "token")
+
+	data, err := ParseWebAppInitData(initData, "token", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if data.QueryID != "AAHdF6IQAAAAAN0XohDhrOrc" || data.User == nil || data.User.ID != 279058397 || !data.User.IsPremium || data.AuthDate.Unix() != now {
+		t.Fatalf("unexpected data %+v", data)
+	}
+
+	if _, err = ParseWebAppInitData(initData, "other", time.Hour); !errors.Is(err, ErrInvalidHash) {
+		t.Fatalf("expected ErrInvalidHash, got %v", err)
+	}
+
+	tampered := strings.Replace(initData, "Vladislav", "Mallory", 1)
+	if _, err = ParseWebAppInitData(tampered, "token", time.Hour); !errors.Is(err, ErrInvalidHash) {
+		t.Fatalf("expected ErrInvalidHash, got %v", err)
+	}
+
+	stale := signWebAppData(url.Values{"auth_date": {strconv.FormatInt(now-7200, 10)}}, "token")
+	if _, err = ParseWebAppInitData(stale, "token", time.Hour); !errors.Is(err, ErrAuthExpired) {
+		t.Fatalf("expected ErrAuthExpired, got %v", err)
+	}
+	if _, err = ParseWebAppInitData(stale, "token", 0); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAnswerWebAppQuery(t *testing.T) {
+	a, reqs := newMockAPI(t, `{"inline_message_id":"inline"}`)
+
+	res, err := a.AnswerWebAppQuery("query", InlineQueryResultArticle{
+		ID:                  "1",
+		Title:               "Result",
+		InputMessageContent: InputTextMessageContent{MessageText: "text"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.Result == nil || res.Result.InlineMessageID != "inline" {
+		t.Fatalf("unexpected result %+v", res.Result)
+	}
+
+	r := <-reqs
+	if r.method != "answerWebAppQuery" || r.query.Get("web_app_query_id") != "query" || !strings.Contains(r.query.Get("result"), `"type":"article"`) {
+		t.Fatalf("unexpected request %+v", r)
+	}
+}